@@ -0,0 +1,132 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// bulkWorkers bounds the number of concurrent requests of bulk operations.
+	bulkWorkers = 8
+	// bulkAttempts is the number of times a bulk operation tries each item.
+	bulkAttempts = 3
+)
+
+// bulkRetryDelay is the delay before the first retry of a failed item. It
+// doubles with every further attempt.
+var bulkRetryDelay = time.Second
+
+// BulkError is returned by bulk operations when some of the items failed. The
+// items not listed succeeded.
+type BulkError struct {
+	// Errors holds the error of each failed item, by the item's ID.
+	Errors map[string]error
+}
+
+// Error returns the string representation of the error.
+func (e *BulkError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", id, e.Errors[id]))
+	}
+	return fmt.Sprintf("%d of the items failed: %s", len(ids), strings.Join(msgs, "; "))
+}
+
+// runBulk calls fn for each of ids with a bounded number of workers, retrying
+// throttled and server errors. It returns a *BulkError listing the ids that
+// failed, if any.
+func runBulk(ctx context.Context, ids []string, fn func(ctx context.Context, id string) error) error {
+	var (
+		mu     sync.Mutex
+		failed = make(map[string]error)
+		wg     sync.WaitGroup
+	)
+
+	items := make(chan string)
+	workers := bulkWorkers
+	if len(ids) < workers {
+		workers = len(ids)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range items {
+				err := retry(ctx, bulkAttempts, func() error { return fn(ctx, id) })
+				if err != nil {
+					mu.Lock()
+					failed[id] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i, id := range ids {
+		select {
+		case items <- id:
+			continue
+		case <-ctx.Done():
+		}
+
+		mu.Lock()
+		for _, id := range ids[i:] {
+			failed[id] = ctx.Err()
+		}
+		mu.Unlock()
+		break
+	}
+	close(items)
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return &BulkError{Errors: failed}
+	}
+	return nil
+}
+
+// retry calls fn until it succeeds, fails with an error that isn't worth
+// retrying or the attempts run out. Throttled requests wait as long as the
+// server asks to, other retries back off exponentially.
+func retry(ctx context.Context, attempts int, fn func() error) error {
+	delay := bulkRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+
+		var tErr *Error
+		if err == nil || attempt == attempts || !errors.As(err, &tErr) || !retryable(tErr) {
+			return err
+		}
+
+		wait := delay
+		if retryAfter, ok := tErr.RetryAfter(); ok {
+			wait = retryAfter
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// retryable reports whether the request that failed with e may succeed when
+// sent again.
+func retryable(e *Error) bool {
+	return e.HTTPStatusCode == http.StatusTooManyRequests || e.HTTPStatusCode >= http.StatusInternalServerError
+}
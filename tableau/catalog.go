@@ -0,0 +1,245 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+const catalogMinAPIVersion = "3.5"
+
+// Database represents a database asset of Tableau Catalog.
+type Database struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	IsCertified       bool   `json:"isCertified"`
+	CertificationNote string `json:"certificationNote"`
+	IsEmbedded        bool   `json:"isEmbedded"`
+	ConnectionType    string `json:"connectionType"`
+	HostName          string `json:"hostName"`
+	Port              int    `json:"port,string"`
+	Type              string `json:"type"`
+	Contact           struct {
+		ID string `json:"id"`
+	} `json:"contact"`
+}
+
+// Table represents a database table asset of Tableau Catalog.
+type Table struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Schema            string `json:"schema"`
+	Description       string `json:"description"`
+	IsCertified       bool   `json:"isCertified"`
+	CertificationNote string `json:"certificationNote"`
+	IsEmbedded        bool   `json:"isEmbedded"`
+	Contact           struct {
+		ID string `json:"id"`
+	} `json:"contact"`
+}
+
+// UpdateCatalogAssetRequest encapsulates the request for updating a database
+// or a table. Empty fields are left unchanged on the server.
+type UpdateCatalogAssetRequest struct {
+	ID                string
+	Description       string
+	IsCertified       *bool
+	CertificationNote string
+	// ContactID is the id of the user to contact about the asset.
+	ContactID string
+}
+
+type updateCatalogAssetPayload struct {
+	Description       string      `json:"description,omitempty"`
+	IsCertified       *bool       `json:"isCertified,omitempty"`
+	CertificationNote string      `json:"certificationNote,omitempty"`
+	Contact           *resourceID `json:"contact,omitempty"`
+}
+
+func (r *UpdateCatalogAssetRequest) payload() *updateCatalogAssetPayload {
+	payload := &updateCatalogAssetPayload{
+		Description:       r.Description,
+		IsCertified:       r.IsCertified,
+		CertificationNote: r.CertificationNote,
+	}
+	if r.ContactID != "" {
+		payload.Contact = &resourceID{ID: r.ContactID}
+	}
+	return payload
+}
+
+type databaseResponse struct {
+	Database *Database `json:"database"`
+}
+
+type queryDatabasesResponse struct {
+	Pagination Pagination
+	Databases  struct {
+		Database []*Database `json:"database"`
+	} `json:"databases"`
+}
+
+type tableResponse struct {
+	Table *Table `json:"table"`
+}
+
+type queryTablesResponse struct {
+	Pagination Pagination
+	Tables     struct {
+		Table []*Table `json:"table"`
+	} `json:"tables"`
+}
+
+type databasesService struct {
+	client *Client
+}
+
+func (ds *databasesService) Query(ctx context.Context, opts ...QueryOption) ([]*Database, *Pagination, error) {
+	err := ds.client.requireAPIVersion(catalogMinAPIVersion, "databases")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/databases", ds.client.SiteID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := ds.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query databases")
+	}
+
+	resp := &queryDatabasesResponse{}
+	err = ds.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Databases.Database, &resp.Pagination, nil
+}
+
+func (ds *databasesService) Get(ctx context.Context, id string) (*Database, error) {
+	err := ds.client.requireAPIVersion(catalogMinAPIVersion, "databases")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/databases/%s", ds.client.SiteID, id)
+	req, err := ds.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get database")
+	}
+
+	resp := &databaseResponse{}
+	err = ds.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Database, nil
+}
+
+func (ds *databasesService) Update(ctx context.Context, updateReq *UpdateCatalogAssetRequest) (*Database, error) {
+	err := ds.client.requireAPIVersion(catalogMinAPIVersion, "databases")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/databases/%s", ds.client.SiteID, updateReq.ID)
+
+	request := struct {
+		Database *updateCatalogAssetPayload `json:"database"`
+	}{
+		Database: updateReq.payload(),
+	}
+
+	req, err := ds.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update database")
+	}
+
+	resp := &databaseResponse{}
+	err = ds.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Database, nil
+}
+
+type tablesService struct {
+	client *Client
+}
+
+func (ts *tablesService) Query(ctx context.Context, opts ...QueryOption) ([]*Table, *Pagination, error) {
+	err := ts.client.requireAPIVersion(catalogMinAPIVersion, "tables")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/tables", ts.client.SiteID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := ts.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query tables")
+	}
+
+	resp := &queryTablesResponse{}
+	err = ts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Tables.Table, &resp.Pagination, nil
+}
+
+func (ts *tablesService) Get(ctx context.Context, id string) (*Table, error) {
+	err := ts.client.requireAPIVersion(catalogMinAPIVersion, "tables")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/tables/%s", ts.client.SiteID, id)
+	req, err := ts.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get table")
+	}
+
+	resp := &tableResponse{}
+	err = ts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Table, nil
+}
+
+func (ts *tablesService) Update(ctx context.Context, updateReq *UpdateCatalogAssetRequest) (*Table, error) {
+	err := ts.client.requireAPIVersion(catalogMinAPIVersion, "tables")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/tables/%s", ts.client.SiteID, updateReq.ID)
+
+	request := struct {
+		Table *updateCatalogAssetPayload `json:"table"`
+	}{
+		Table: updateReq.payload(),
+	}
+
+	req, err := ts.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update table")
+	}
+
+	resp := &tableResponse{}
+	err = ts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Table, nil
+}
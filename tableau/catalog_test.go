@@ -0,0 +1,74 @@
+package tableau
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestCatalogUpdate(t *testing.T) {
+	certified := true
+
+	tests := []struct {
+		desc     string
+		req      *UpdateCatalogAssetRequest
+		wantBody map[string]interface{}
+	}{
+		{
+			desc: "certifies with contact",
+			req: &UpdateCatalogAssetRequest{
+				ID:                "asset-id",
+				Description:       "Orders of the web shop",
+				IsCertified:       &certified,
+				CertificationNote: "reviewed by data platform",
+				ContactID:         "user-id",
+			},
+			wantBody: map[string]interface{}{
+				"description":       "Orders of the web shop",
+				"isCertified":       true,
+				"certificationNote": "reviewed by data platform",
+				"contact":           map[string]interface{}{"id": "user-id"},
+			},
+		},
+		{
+			desc: "omits unset fields",
+			req: &UpdateCatalogAssetRequest{
+				ID:          "asset-id",
+				Description: "Orders of the web shop",
+			},
+			wantBody: map[string]interface{}{
+				"description": "Orders of the web shop",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, asset := range []string{"database", "table"} {
+			t.Run(asset+" "+tt.desc, func(t *testing.T) {
+				c := qt.New(t)
+				client := newTestClient(t, "/api/3.5/sites/site-id/"+asset+"s/asset-id", func(w http.ResponseWriter, r *http.Request) {
+					c.Check(r.Method, qt.Equals, http.MethodPut)
+					body, err := ioutil.ReadAll(r.Body)
+					c.Check(err, qt.IsNil)
+					c.Check(string(body), qt.JSONEquals, map[string]interface{}{asset: tt.wantBody})
+					_, _ = w.Write([]byte(`{"` + asset + `": {"id": "asset-id", "isCertified": true, "contact": {"id": "user-id"}}}`))
+				}, WithAPIVersion("3.5"))
+
+				if asset == "database" {
+					db, err := client.Databases.Update(context.Background(), tt.req)
+					c.Assert(err, qt.IsNil)
+					c.Assert(db.IsCertified, qt.IsTrue)
+					c.Assert(db.Contact.ID, qt.Equals, "user-id")
+					return
+				}
+				table, err := client.Tables.Update(context.Background(), tt.req)
+				c.Assert(err, qt.IsNil)
+				c.Assert(table.IsCertified, qt.IsTrue)
+				c.Assert(table.Contact.ID, qt.Equals, "user-id")
+			})
+		}
+	}
+}
@@ -2,13 +2,22 @@ package tableau
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/pkg/errors"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -21,45 +30,181 @@ const (
 	userAgent      = "go-tableau/" + libraryVersion
 )
 
+// defaultAPIVersion is the REST API version used unless WithAPIVersion is given.
+const defaultAPIVersion = "3.4"
+
+// defaultTimeout bounds requests whose context carries no deadline.
+const defaultTimeout = 30 * time.Second
+
 // Client encapsulates a client that talks to the Tableau API
 type Client struct {
 	client *http.Client
 
 	UserAgent string
 
+	// mu guards headers and tokenExpiresAt, which are rewritten whenever the
+	// token changes.
+	mu      sync.RWMutex
 	headers map[string]string
 
 	baseURL *url.URL
 
 	SiteID string
 
-	DataSources *dataSourcesService
-	Projects    *projectsService
+	apiVersion string
+
+	tokenExpiresAt time.Time
+
+	compressRequests bool
+
+	timeout time.Duration
+
+	CustomViews         *customViewsService
+	DataQualityWarnings *dataQualityWarningsService
+	DataSources         *dataSourcesService
+	Databases           *databasesService
+	ExtractRefreshTasks *extractRefreshTasksService
+	Favorites           *favoritesService
+	FileUploads         *fileUploadsService
+	FlowRunTasks        *flowRunTasksService
+	Flows               *flowsService
+	GroupSets           *groupSetsService
+	Groups              *groupsService
+	Jobs                *jobsService
+	Labels              *labelsService
+	LinkedTasks         *linkedTasksService
+	Metadata            *metadataService
+	Projects            *projectsService
+	Schedules           *schedulesService
+	Sites               *sitesService
+	Subscriptions       *subscriptionsService
+	Tables              *tablesService
+	Users               *usersService
+	Views               *viewsService
+	Webhooks            *webhooksService
+	Workbooks           *workbooksService
 }
 
-// NewClient instantiates an instance of the Tableau API client.
-func NewClient(serverAddr, personalAccessTokenName, personalAccessTokenSecret, site string) (*Client, error) {
-	baseURL, err := url.Parse(serverAddr + "/api/3.4/")
-	if err != nil {
-		return nil, err
+// ClientOption configures a Client on creation.
+type ClientOption func(*Client) error
+
+// WithTimeout returns a ClientOption that sets the timeout applied to requests
+// whose context has no deadline, including the initial sign in.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithAPIVersion returns a ClientOption that sets the REST API version used
+// by the client, i.e; "3.15". Some endpoints require a newer version than the
+// default.
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) error {
+		if _, _, err := parseAPIVersion(version); err != nil {
+			return err
+		}
+		c.apiVersion = version
+		return nil
+	}
+}
+
+// WithRequestCompression returns a ClientOption that gzip compresses the body
+// of requests other than GET and sets the "Content-Encoding" header
+// accordingly. Requests without a body are sent as is.
+func WithRequestCompression() ClientOption {
+	return func(c *Client) error {
+		c.compressRequests = true
+		return nil
 	}
+}
+
+// NewClient instantiates an instance of the Tableau API client.
+func NewClient(serverAddr, personalAccessTokenName, personalAccessTokenSecret, site string, opts ...ClientOption) (*Client, error) {
+	return NewClientWithContext(context.Background(), serverAddr, personalAccessTokenName, personalAccessTokenSecret, site, opts...)
+}
 
+// NewClientWithContext instantiates an instance of the Tableau API client,
+// using ctx for the initial sign in.
+func NewClientWithContext(ctx context.Context, serverAddr, personalAccessTokenName, personalAccessTokenSecret, site string, opts ...ClientOption) (*Client, error) {
 	c := &Client{
-		client:    cleanhttp.DefaultClient(),
-		baseURL:   baseURL,
-		UserAgent: userAgent,
-		headers:   make(map[string]string, 0),
+		client:     cleanhttp.DefaultClient(),
+		UserAgent:  userAgent,
+		headers:    make(map[string]string, 0),
+		apiVersion: defaultAPIVersion,
+		timeout:    defaultTimeout,
 	}
 
-	err = c.signIn(personalAccessTokenName, personalAccessTokenSecret, site)
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	baseURL, err := parseServerAddr(serverAddr, c.apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	c.baseURL = baseURL
+
+	err = c.signIn(ctx, personalAccessTokenName, personalAccessTokenSecret, site)
 	if err != nil {
 		return nil, err
 	}
+	c.CustomViews = &customViewsService{client: c}
+	c.DataQualityWarnings = &dataQualityWarningsService{client: c}
 	c.DataSources = &dataSourcesService{client: c}
+	c.Databases = &databasesService{client: c}
+	c.ExtractRefreshTasks = &extractRefreshTasksService{client: c}
+	c.Favorites = &favoritesService{client: c}
+	c.FileUploads = &fileUploadsService{client: c}
+	c.FlowRunTasks = &flowRunTasksService{client: c}
+	c.Flows = &flowsService{client: c}
+	c.GroupSets = &groupSetsService{client: c}
+	c.Groups = &groupsService{client: c}
+	c.Jobs = &jobsService{client: c}
+	c.Labels = &labelsService{client: c}
+	c.LinkedTasks = &linkedTasksService{client: c}
+	c.Metadata = &metadataService{client: c}
 	c.Projects = &projectsService{client: c}
+	c.Schedules = &schedulesService{client: c}
+	c.Sites = &sitesService{client: c}
+	c.Subscriptions = &subscriptionsService{client: c}
+	c.Tables = &tablesService{client: c}
+	c.Users = &usersService{client: c}
+	c.Views = &viewsService{client: c}
+	c.Webhooks = &webhooksService{client: c}
+	c.Workbooks = &workbooksService{client: c}
 	return c, nil
 }
 
+// parseServerAddr validates serverAddr and returns the base URL of the REST
+// API of the given version on that server.
+func parseServerAddr(serverAddr, apiVersion string) (*url.URL, error) {
+	serverAddr = strings.TrimRight(serverAddr, "/")
+
+	u, err := url.Parse(serverAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid server address")
+	}
+
+	switch {
+	case u.Scheme == "":
+		return nil, errors.New("invalid server address: missing scheme")
+	case u.Scheme != "http" && u.Scheme != "https":
+		return nil, errors.Errorf("invalid server address: unsupported scheme %q", u.Scheme)
+	case u.Host == "":
+		return nil, errors.New("invalid server address: missing host")
+	}
+
+	return url.Parse(serverAddr + "/api/" + apiVersion + "/")
+}
+
 type signInRequest struct {
 	Credentials credentials `json:"credentials"`
 }
@@ -86,7 +231,7 @@ type signInResponse struct {
 }
 
 // sign in to Tableau API and fetch token for futures requests.
-func (c *Client) signIn(personalAccessTokenName, personalAccessTokenSecret, siteName string) error {
+func (c *Client) signIn(ctx context.Context, personalAccessTokenName, personalAccessTokenSecret, siteName string) error {
 	signInRequest := signInRequest{
 		Credentials: credentials{
 			TokenName:   personalAccessTokenName,
@@ -103,17 +248,210 @@ func (c *Client) signIn(personalAccessTokenName, personalAccessTokenSecret, site
 	}
 
 	resp := &signInResponse{}
-	err = c.do(context.TODO(), req, resp)
+	err = c.do(ctx, req, resp)
 	if err != nil {
 		return err
 	}
-	c.headers["X-Tableau-Auth"] = resp.Credentials.Token
-	c.SiteID = resp.Credentials.Site.ID
+	c.setCredentials(resp)
+	return nil
+}
+
+type switchSiteRequest struct {
+	Site site `json:"site"`
+}
+
+// SwitchSite switches the signed in session to the site identified by
+// contentUrl. The token and SiteID of the client are replaced with the ones
+// scoped to the new site, so existing services target it from then on. As
+// SiteID is read by every service call, SwitchSite must not run concurrently
+// with other requests.
+func (c *Client) SwitchSite(ctx context.Context, contentUrl string) error {
+	switchReq := switchSiteRequest{
+		Site: site{
+			ContentUrl: contentUrl,
+		},
+	}
+
+	req, err := c.newRequest(http.MethodPost, "auth/switchSite", switchReq)
+	if err != nil {
+		return errors.Wrap(err, "error creating request auth/switchSite")
+	}
+
+	resp := &signInResponse{}
+	err = c.do(ctx, req, resp)
+	if err != nil {
+		return err
+	}
+	c.setCredentials(resp)
 	return nil
 }
 
+// SignOut signs out of the server, revoking the session token. The client
+// can't be used for further requests afterwards.
+func (c *Client) SignOut(ctx context.Context) error {
+	req, err := c.newRequest(http.MethodPost, "auth/signout", nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request auth/signout")
+	}
+
+	err = c.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+	c.setToken("", time.Time{})
+	return nil
+}
+
+// Close signs out of the server unless the client is already signed out, so
+// the session token isn't left valid until it expires. It implements
+// io.Closer.
+func (c *Client) Close() error {
+	c.mu.RLock()
+	token := c.headers["X-Tableau-Auth"]
+	c.mu.RUnlock()
+	if token == "" {
+		return nil
+	}
+	return c.SignOut(context.Background())
+}
+
+// setCredentials stores the token and site returned by an auth endpoint.
+func (c *Client) setCredentials(resp *signInResponse) {
+	c.setToken(resp.Credentials.Token, parseTokenExpiration(resp.Credentials.EstimatedTimeToExpiration, time.Now()))
+	c.SiteID = resp.Credentials.Site.ID
+}
+
+// setToken replaces the token sent with every request. It is safe to call
+// while other requests are in flight.
+func (c *Client) setToken(token string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.headers["X-Tableau-Auth"] = token
+	c.tokenExpiresAt = expiresAt
+}
+
+// TokenExpiresAt returns the estimated expiry of the session token. It is the
+// zero time if the server didn't report an expiry the client understands.
+func (c *Client) TokenExpiresAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tokenExpiresAt
+}
+
+// TokenValid reports whether the client holds a token that hasn't expired. A
+// token with an unknown expiry is considered valid.
+func (c *Client) TokenValid() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.headers["X-Tableau-Auth"] == "" {
+		return false
+	}
+	return c.tokenExpiresAt.IsZero() || time.Now().Before(c.tokenExpiresAt)
+}
+
+// parseTokenExpiration converts the estimatedTimeToExpiration of a sign in
+// response, given either as "DD:HH:MM:SS", "HH:MM:SS" or a duration string like
+// "240m0s", into an expiry relative to now. It returns the zero time for
+// values it can't parse.
+func parseTokenExpiration(v string, now time.Time) time.Time {
+	if v == "" {
+		return time.Time{}
+	}
+
+	if !strings.Contains(v, ":") {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return time.Time{}
+		}
+		return now.Add(d)
+	}
+
+	parts := strings.Split(v, ":")
+	if len(parts) < 3 || len(parts) > 4 {
+		return time.Time{}
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}[4-len(parts):]
+	var d time.Duration
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return time.Time{}
+		}
+		d += time.Duration(n) * units[i]
+	}
+	return now.Add(d)
+}
+
+// ServerInfo represents the version information of a Tableau server.
+type ServerInfo struct {
+	ProductVersion string
+	BuildNumber    string
+	RestApiVersion string
+}
+
+type serverInfoResponse struct {
+	ServerInfo struct {
+		ProductVersion struct {
+			Value string `json:"value"`
+			Build string `json:"build"`
+		} `json:"productVersion"`
+		RestApiVersion string `json:"restApiVersion"`
+	} `json:"serverInfo"`
+}
+
+// ServerInfo returns the product version, build number and the highest REST
+// API version supported by the server. It requires no sign in.
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	req, err := c.newRequest(http.MethodGet, "serverinfo", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for server info")
+	}
+
+	resp := &serverInfoResponse{}
+	err = c.do(ctx, req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerInfo{
+		ProductVersion: resp.ServerInfo.ProductVersion.Value,
+		BuildNumber:    resp.ServerInfo.ProductVersion.Build,
+		RestApiVersion: resp.ServerInfo.RestApiVersion,
+	}, nil
+}
+
+// SupportsAPIVersion reports whether the server supports the REST API version
+// min, i.e; "3.21", so callers can branch on server capabilities. It reports
+// false when either version can't be parsed.
+func (si *ServerInfo) SupportsAPIVersion(min string) bool {
+	major, minor, err := parseAPIVersion(si.RestApiVersion)
+	if err != nil {
+		return false
+	}
+	minMajor, minMinor, err := parseAPIVersion(min)
+	if err != nil {
+		return false
+	}
+	return major > minMajor || (major == minMajor && minor >= minMinor)
+}
+
 // do makes an HTTP request and populates the given struct v from the response.
+// If ctx has no deadline the client timeout is applied.
 func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	return c.send(ctx, req, v)
+}
+
+// send makes an HTTP request and populates the given struct v from the
+// response without applying the client timeout. It is meant for uploads, whose
+// duration depends on the size of the payload.
+func (c *Client) send(ctx context.Context, req *http.Request, v interface{}) error {
 	req = req.WithContext(ctx)
 	res, err := c.client.Do(req)
 	if err != nil {
@@ -124,6 +462,41 @@ func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error
 	return c.handleResponse(ctx, res, v)
 }
 
+// doStream makes an HTTP request for endpoints that reply with binary content
+// and returns the response with its body unread. Error responses are decoded
+// the same way as in `Client.do`. The caller must close the response body.
+func (c *Client) doStream(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 400 {
+		defer res.Body.Close()
+		return nil, c.handleResponse(ctx, res, nil)
+	}
+
+	return res, nil
+}
+
+// download makes an HTTP request for binary content and copies the response
+// body to w. It returns the Content-Type of the response.
+func (c *Client) download(ctx context.Context, req *http.Request, w io.Writer) (string, error) {
+	res, err := c.doStream(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	_, err = io.Copy(w, res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Header.Get("Content-Type"), nil
+}
+
 // handleResponse makes an HTTP request and populates the given struct v from
 // the response.  This is meant for internal testing and shouldn't be used
 // directly. Instead please use `Client.do`.
@@ -134,48 +507,13 @@ func (c *Client) handleResponse(ctx context.Context, res *http.Response, v inter
 	}
 
 	if res.StatusCode >= 400 {
-		// errorResponse represents an error response from the API
-		type errorResponse struct {
-			Error struct {
-				Summary string `json:"summary"`
-				Detail  string `json:"detail"`
-				Code    string `json:"code"`
-			}
-		}
+		err = newResponseError(res, out)
 
-		errorRes := &errorResponse{}
-		err = json.Unmarshal(out, errorRes)
-		if err != nil {
-			var jsonErr *json.SyntaxError
-			if errors.As(err, &jsonErr) {
-				return &Error{
-					msg:  "malformed error response body received",
-					Code: ErrCodeInternal,
-					Meta: map[string]string{
-						"body":        string(out),
-						"err":         jsonErr.Error(),
-						"http_status": http.StatusText(res.StatusCode),
-					},
-				}
-			}
-			return err
-		}
-
-		if *errorRes == (errorResponse{}) {
-			return &Error{
-				msg:  "internal error, response body doesn't match error type signature",
-				Code: ErrCodeInternal,
-				Meta: map[string]string{
-					"body":        string(out),
-					"http_status": http.StatusText(res.StatusCode),
-				},
-			}
-		}
-
-		return &Error{
-			msg:  errorRes.Error.Summary + ": " + errorRes.Error.Detail,
-			Code: errorRes.Error.Code,
+		var tErr *Error
+		if res.StatusCode == http.StatusTooManyRequests && errors.As(err, &tErr) {
+			addRateLimitMeta(tErr, res.Header)
 		}
+		return err
 	}
 
 	// this means we don't care about unmarshaling the response body into v
@@ -188,8 +526,9 @@ func (c *Client) handleResponse(ctx context.Context, res *http.Response, v inter
 		var jsonErr *json.SyntaxError
 		if errors.As(err, &jsonErr) {
 			return &Error{
-				msg:  "malformed response body received",
-				Code: ErrCodeInternal,
+				msg:            "malformed response body received",
+				Code:           ErrCodeInternal,
+				HTTPStatusCode: res.StatusCode,
 				Meta: map[string]string{
 					"body":        string(out),
 					"http_status": http.StatusText(res.StatusCode),
@@ -202,6 +541,71 @@ func (c *Client) handleResponse(ctx context.Context, res *http.Response, v inter
 	return nil
 }
 
+// newResponseError creates an error from an error response of the API.
+func newResponseError(res *http.Response, out []byte) error {
+	// errorResponse represents an error response from the API
+	type errorResponse struct {
+		Error struct {
+			Summary string `json:"summary"`
+			Detail  string `json:"detail"`
+			Code    string `json:"code"`
+		}
+	}
+
+	errorRes := &errorResponse{}
+	err := json.Unmarshal(out, errorRes)
+	if err != nil {
+		// the status code is kept even if the body can't be decoded, i.e; a
+		// proxy in front of the server replied with an HTML page.
+		return &Error{
+			msg:            "malformed error response body received",
+			Code:           ErrCodeInternal,
+			HTTPStatusCode: res.StatusCode,
+			Meta: map[string]string{
+				"body":        string(out),
+				"err":         err.Error(),
+				"http_status": http.StatusText(res.StatusCode),
+			},
+		}
+	}
+
+	if *errorRes == (errorResponse{}) {
+		return &Error{
+			msg:            "internal error, response body doesn't match error type signature",
+			Code:           ErrCodeInternal,
+			HTTPStatusCode: res.StatusCode,
+			Meta: map[string]string{
+				"body":        string(out),
+				"http_status": http.StatusText(res.StatusCode),
+			},
+		}
+	}
+
+	return &Error{
+		msg:            errorRes.Error.Summary + ": " + errorRes.Error.Detail,
+		Code:           errorRes.Error.Code,
+		HTTPStatusCode: res.StatusCode,
+	}
+}
+
+// addRateLimitMeta copies the throttling headers of a 429 response into the
+// Meta of e, so callers can tell how long to back off.
+func addRateLimitMeta(e *Error, header http.Header) {
+	if e.Meta == nil {
+		e.Meta = make(map[string]string)
+	}
+
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		e.Meta["retry_after"] = retryAfter
+	}
+
+	for key := range header {
+		if strings.HasPrefix(key, "X-Tableau-") {
+			e.Meta[strings.ToLower(strings.ReplaceAll(key, "-", "_"))] = header.Get(key)
+		}
+	}
+}
+
 func (c *Client) newRequest(method string, path string, body interface{}) (*http.Request, error) {
 	u, err := c.baseURL.Parse(path)
 	if err != nil {
@@ -224,24 +628,177 @@ func (c *Client) newRequest(method string, path string, body interface{}) (*http
 			}
 		}
 
+		if c.compressRequests && buf.Len() > 0 {
+			buf, err = gzipBuffer(buf)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		req, err = http.NewRequest(method, u.String(), buf)
 		if err != nil {
 			return nil, err
 		}
 
 		req.Header.Set("Content-Type", jsonMediaType)
+		if c.compressRequests && buf.Len() > 0 {
+			req.Header.Set("Content-Encoding", "gzip")
+		}
 	}
 
 	req.Header.Set("Accept", jsonMediaType)
 	req.Header.Set("User-Agent", c.UserAgent)
 
+	c.mu.RLock()
 	for k, v := range c.headers {
 		req.Header.Set(k, v)
 	}
+	c.mu.RUnlock()
 
 	return req, nil
 }
 
+// newMultipartRequest creates a multipart/mixed request as used by the publish
+// endpoints. The first part holds payload encoded as JSON under the name
+// "request_payload", the second streams file under fileField, so large files
+// are never held in memory.
+func (c *Client) newMultipartRequest(method, path string, payload interface{}, fileField, fileName string, file io.Reader) (*http.Request, error) {
+	u, err := c.baseURL.Parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	boundary := multipart.NewWriter(ioutil.Discard).Boundary()
+	pr, pw := io.Pipe()
+
+	go func() {
+		var w io.Writer = pw
+		var zw *gzip.Writer
+		if c.compressRequests {
+			zw = gzip.NewWriter(pw)
+			w = zw
+		}
+
+		err := writeMultipart(w, boundary, payload, fileField, fileName, file)
+		if err == nil && zw != nil {
+			err = zw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequest(method, u.String(), pr)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+boundary)
+	if c.compressRequests {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	req.Header.Set("Accept", jsonMediaType)
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	c.mu.RLock()
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	c.mu.RUnlock()
+
+	return req, nil
+}
+
+// writeMultipart writes the parts of a publish request to w.
+func writeMultipart(w io.Writer, boundary string, payload interface{}, fileField, fileName string, file io.Reader) error {
+	mw := multipart.NewWriter(w)
+	err := mw.SetBoundary(boundary)
+	if err != nil {
+		return err
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `name="request_payload"`)
+	header.Set("Content-Type", jsonMediaType)
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	// some endpoints, i.e; file uploads, expect an empty payload part
+	if payload != nil {
+		err = json.NewEncoder(part).Encode(payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	if file != nil {
+		header = make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`name=%q; filename=%q`, fileField, fileName))
+		header.Set("Content-Type", "application/octet-stream")
+		part, err = mw.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(part, file)
+		if err != nil {
+			return err
+		}
+	}
+
+	return mw.Close()
+}
+
+// gzipBuffer returns the gzip compressed content of buf.
+func gzipBuffer(buf *bytes.Buffer) (*bytes.Buffer, error) {
+	out := new(bytes.Buffer)
+	zw := gzip.NewWriter(out)
+	_, err := buf.WriteTo(zw)
+	if err != nil {
+		return nil, err
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// requireAPIVersion returns an error if the configured REST API version is
+// lower than min. feature describes what needs the version in the error.
+func (c *Client) requireAPIVersion(min, feature string) error {
+	major, minor, err := parseAPIVersion(c.apiVersion)
+	if err != nil {
+		return err
+	}
+	minMajor, minMinor, err := parseAPIVersion(min)
+	if err != nil {
+		return err
+	}
+
+	if major < minMajor || (major == minMajor && minor < minMinor) {
+		return errors.Errorf("%s requires REST API version %s or later, client is configured for %s (see WithAPIVersion)", feature, min, c.apiVersion)
+	}
+	return nil
+}
+
+// parseAPIVersion splits a REST API version like "3.4" into its parts.
+func parseAPIVersion(version string) (int, int, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("invalid API version %q", version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid API version %q", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid API version %q", version)
+	}
+	return major, minor, nil
+}
+
 // Error represents common errors originating from the Client.
 type Error struct {
 	// msg contains the human readable string
@@ -250,11 +807,55 @@ type Error struct {
 	// code specifies the error code. i.e; NotFound, RateLimited, etc...
 	Code string
 
+	// HTTPStatusCode is the HTTP status code of the response the error
+	// originates from.
+	HTTPStatusCode int
+
 	// Meta contains additional information depending on the error code. As an
 	// example, if the Code is "ErrResponseMalformed", the map will be: ["body"]
 	// = "body of the response"
 	Meta map[string]string
+
+	// sentinel is the package level error the error matches, if any.
+	sentinel error
 }
 
 // Error returns the string representation of the error.
 func (e *Error) Error() string { return e.msg }
+
+// Unwrap returns the sentinel error, i.e; ErrProjectNotFound, the error
+// corresponds to, so it can be matched with errors.Is.
+func (e *Error) Unwrap() error { return e.sentinel }
+
+// withSentinel attaches sentinel to err if it's an Error with the given code.
+func withSentinel(err error, code string, sentinel error) error {
+	var tErr *Error
+	if errors.As(err, &tErr) && tErr.Code == code {
+		tErr.sentinel = sentinel
+	}
+	return err
+}
+
+// RetryAfter returns how long to wait before retrying a throttled request, as
+// advertised by the Retry-After header of a 429 response. The header may be
+// given either in seconds or as an HTTP date.
+func (e *Error) RetryAfter() (time.Duration, bool) {
+	retryAfter, ok := e.Meta["retry_after"]
+	if !ok {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(retryAfter); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -1,14 +1,42 @@
 package tableau
 
 import (
+	"compress/gzip"
 	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 )
 
+// newTestClient returns a client signed in to a test server as the site
+// "site-id". Requests whose path starts with path are served by handler and
+// any other request gets a new session.
+func newTestClient(t *testing.T, path string, handler http.HandlerFunc, opts ...ClientOption) *Client {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, path) {
+			_, _ = w.Write([]byte(`{"credentials": {"site": {"id": "site-id"}, "token": "token"}}`))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := NewClient(ts.URL, "", "", "", opts...)
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	return client
+}
+
 func TestDo(t *testing.T) {
 	tests := []struct {
 		desc          string
@@ -108,3 +136,296 @@ func TestDo(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClientTimeout(t *testing.T) {
+	c := qt.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	_, err := NewClient(ts.URL, "", "", "", WithTimeout(10*time.Millisecond))
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewClientWithContext(ctx, ts.URL, "", "", "")
+	c.Assert(errors.Is(err, context.Canceled), qt.IsTrue)
+}
+
+func TestRequireAPIVersion(t *testing.T) {
+	tests := []struct {
+		desc       string
+		apiVersion string
+		min        string
+		wantErr    bool
+	}{
+		{desc: "equal version", apiVersion: "3.6", min: "3.6"},
+		{desc: "higher minor version", apiVersion: "3.15", min: "3.6"},
+		{desc: "higher major version", apiVersion: "4.0", min: "3.6"},
+		{desc: "lower minor version", apiVersion: "3.4", min: "3.6", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			client := &Client{apiVersion: tt.apiVersion}
+
+			err := client.requireAPIVersion(tt.min, "feature")
+			if tt.wantErr {
+				c.Assert(err, qt.ErrorMatches, "feature requires REST API version 3.6 or later.*")
+			} else {
+				c.Assert(err, qt.IsNil)
+			}
+		})
+	}
+}
+
+func TestServerInfoSupportsAPIVersion(t *testing.T) {
+	c := qt.New(t)
+	info := &ServerInfo{RestApiVersion: "3.19"}
+
+	c.Assert(info.SupportsAPIVersion("3.6"), qt.IsTrue)
+	c.Assert(info.SupportsAPIVersion("3.19"), qt.IsTrue)
+	c.Assert(info.SupportsAPIVersion("3.21"), qt.IsFalse)
+	c.Assert(info.SupportsAPIVersion("invalid"), qt.IsFalse)
+}
+
+func TestHandleResponseRateLimited(t *testing.T) {
+	c := qt.New(t)
+
+	res := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After":               []string{"30"},
+			"X-Tableau-Ratelimit-Limit": []string{"100"},
+		},
+		Body: ioutil.NopCloser(strings.NewReader(`{"error": {"summary": "Too Many Requests", "detail": "slow down", "code": "429000"}}`)),
+	}
+
+	client := &Client{}
+	err := client.handleResponse(context.Background(), res, nil)
+
+	var tErr *Error
+	c.Assert(errors.As(err, &tErr), qt.IsTrue)
+	c.Assert(tErr.Code, qt.Equals, "429000")
+	c.Assert(tErr.Meta["retry_after"], qt.Equals, "30")
+	c.Assert(tErr.Meta["x_tableau_ratelimit_limit"], qt.Equals, "100")
+
+	retryAfter, ok := tErr.RetryAfter()
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(retryAfter, qt.Equals, 30*time.Second)
+}
+
+func TestHandleResponseHTTPStatusCode(t *testing.T) {
+	tests := []struct {
+		desc       string
+		statusCode int
+		response   string
+		wantCode   string
+		wantMsg    string
+	}{
+		{
+			desc:       "error envelope",
+			statusCode: http.StatusNotFound,
+			response:   `{"error": {"summary": "Resource Not Found", "detail": "Project not found", "code": "404005"}}`,
+			wantCode:   "404005",
+			wantMsg:    "Resource Not Found: Project not found",
+		},
+		{
+			desc:       "malformed body",
+			statusCode: http.StatusBadGateway,
+			response:   `<html>Bad Gateway</html>`,
+			wantCode:   ErrCodeInternal,
+			wantMsg:    "malformed error response body received",
+		},
+		{
+			desc:       "empty body",
+			statusCode: http.StatusNotFound,
+			response:   ``,
+			wantCode:   ErrCodeInternal,
+			wantMsg:    "malformed error response body received",
+		},
+		{
+			desc:       "body without error envelope",
+			statusCode: http.StatusInternalServerError,
+			response:   `{}`,
+			wantCode:   ErrCodeInternal,
+			wantMsg:    "internal error, response body doesn't match error type signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			res := &http.Response{
+				StatusCode: tt.statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader(tt.response)),
+			}
+
+			client := &Client{}
+			err := client.handleResponse(context.Background(), res, nil)
+
+			var tErr *Error
+			c.Assert(errors.As(err, &tErr), qt.IsTrue)
+			c.Assert(tErr.HTTPStatusCode, qt.Equals, tt.statusCode)
+			c.Assert(tErr.Code, qt.Equals, tt.wantCode)
+			c.Assert(tErr.Error(), qt.Equals, tt.wantMsg)
+		})
+	}
+}
+
+func TestParseServerAddr(t *testing.T) {
+	tests := []struct {
+		desc       string
+		serverAddr string
+		want       string
+		wantErr    string
+	}{
+		{desc: "https address", serverAddr: "https://tableau.example.com", want: "https://tableau.example.com/api/3.4/"},
+		{desc: "trailing slash", serverAddr: "https://tableau.example.com/", want: "https://tableau.example.com/api/3.4/"},
+		{desc: "missing scheme", serverAddr: "tableau.example.com", wantErr: "invalid server address: missing scheme"},
+		{desc: "unsupported scheme", serverAddr: "ftp://tableau.example.com", wantErr: `invalid server address: unsupported scheme "ftp"`},
+		{desc: "missing host", serverAddr: "https://", wantErr: "invalid server address: missing host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+
+			u, err := parseServerAddr(tt.serverAddr, "3.4")
+			if tt.wantErr != "" {
+				c.Assert(err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(u.String(), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestParseTokenExpiration(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc  string
+		value string
+		want  time.Time
+	}{
+		{desc: "days hours minutes seconds", value: "1:02:03:04", want: now.Add(26*time.Hour + 3*time.Minute + 4*time.Second)},
+		{desc: "hours minutes seconds", value: "04:00:00", want: now.Add(4 * time.Hour)},
+		{desc: "duration string", value: "240m0s", want: now.Add(4 * time.Hour)},
+		{desc: "empty", value: "", want: time.Time{}},
+		{desc: "unexpected format", value: "soon", want: time.Time{}},
+		{desc: "non numeric part", value: "1:xx:00:00", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			c.Assert(parseTokenExpiration(tt.value, now), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestRequestCompression(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			c.Assert(r.Header.Get("Content-Encoding"), qt.Equals, "")
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+
+		c.Assert(r.Header.Get("Content-Encoding"), qt.Equals, "gzip")
+		zr, err := gzip.NewReader(r.Body)
+		c.Assert(err, qt.IsNil)
+		body, err := ioutil.ReadAll(zr)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(body), qt.Equals, `{"project":{"name":"compressed"}}`+"\n")
+
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": {"summary": "Bad Request", "detail": "invalid", "code": "400000"}}`))
+	}, WithRequestCompression())
+
+	_, err := client.Projects.Query(context.Background())
+	c.Assert(err, qt.IsNil)
+
+	_, err = client.Projects.Create(context.Background(), &CreateProjectRequest{Name: "compressed"})
+	var tErr *Error
+	c.Assert(errors.As(err, &tErr), qt.IsTrue)
+	c.Assert(tErr.Code, qt.Equals, "400000")
+}
+
+func TestConcurrentTokenRefresh(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"projects": {"project": []}}`))
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			client.setToken(fmt.Sprintf("token-%d", i), time.Now().Add(time.Hour))
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Projects.Query(context.Background())
+			c.Check(err, qt.IsNil)
+			c.Check(client.TokenValid(), qt.IsTrue)
+		}()
+	}
+
+	wg.Wait()
+	<-done
+}
+
+func TestSwitchSite(t *testing.T) {
+	c := qt.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/3.4/auth/switchSite":
+			c.Check(r.Header.Get("X-Tableau-Auth"), qt.Equals, "token")
+			_, _ = w.Write([]byte(`{"credentials": {"site": {"id": "other-site-id"}, "token": "other-token"}}`))
+		case "/api/3.4/sites/other-site-id/projects":
+			c.Check(r.Header.Get("X-Tableau-Auth"), qt.Equals, "other-token")
+			_, _ = w.Write([]byte(`{"projects": {"project": []}}`))
+		default:
+			_, _ = w.Write([]byte(`{"credentials": {"site": {"id": "site-id"}, "token": "token"}}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	client, err := NewClient(ts.URL, "", "", "")
+	c.Assert(err, qt.IsNil)
+
+	err = client.SwitchSite(context.Background(), "other")
+	c.Assert(err, qt.IsNil)
+	c.Assert(client.SiteID, qt.Equals, "other-site-id")
+
+	_, err = client.Projects.Query(context.Background())
+	c.Assert(err, qt.IsNil)
+}
+
+func TestClose(t *testing.T) {
+	c := qt.New(t)
+	signOuts := 0
+	client := newTestClient(t, "/api/3.4/auth/signout", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Header.Get("X-Tableau-Auth"), qt.Equals, "token")
+		signOuts++
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c.Assert(client.Close(), qt.IsNil)
+	c.Assert(client.Close(), qt.IsNil)
+	c.Assert(signOuts, qt.Equals, 1)
+}
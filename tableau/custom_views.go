@@ -0,0 +1,280 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+const customViewsMinAPIVersion = "3.18"
+
+// CustomView represents a Tableau custom view, a saved state of a view.
+type CustomView struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Shared bool   `json:"shared"`
+	View   struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"view"`
+	Workbook struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"workbook"`
+	Owner struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"owner"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	LastAccessedAt time.Time `json:"lastAccessedAt"`
+}
+
+// UpdateCustomViewRequest encapsulates the request for updating a custom
+// view. Empty fields are left unchanged on the server.
+type UpdateCustomViewRequest struct {
+	ID      string
+	Name    string
+	OwnerID string
+}
+
+type updateCustomViewPayload struct {
+	Name  string      `json:"name,omitempty"`
+	Owner *resourceID `json:"owner,omitempty"`
+}
+
+// PublishCustomViewRequest encapsulates the request for publishing a custom
+// view definition previously obtained with Download.
+type PublishCustomViewRequest struct {
+	Name       string
+	WorkbookID string
+	OwnerID    string
+	Shared     bool
+	// FileName is the name of the uploaded .json definition.
+	FileName string
+}
+
+type publishCustomViewPayload struct {
+	Name     string      `json:"name"`
+	Shared   bool        `json:"shared"`
+	Workbook resourceID  `json:"workbook"`
+	Owner    *resourceID `json:"owner,omitempty"`
+}
+
+// CustomViewDefaultResult is the outcome of setting a custom view as the
+// default view of a single user.
+type CustomViewDefaultResult struct {
+	Success bool `json:"success"`
+	User    struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+type customViewDefaultResultsResponse struct {
+	Results struct {
+		Result []*CustomViewDefaultResult `json:"customViewAsUserDefaultViewResult"`
+	} `json:"customViewAsUserDefaultResults"`
+}
+
+type customViewResponse struct {
+	CustomView *CustomView `json:"customView"`
+}
+
+type queryCustomViewsResponse struct {
+	Pagination  Pagination
+	CustomViews struct {
+		CustomView []*CustomView `json:"customView"`
+	} `json:"customViews"`
+}
+
+type customViewsService struct {
+	client *Client
+}
+
+func (cvs *customViewsService) Query(ctx context.Context, opts ...QueryOption) ([]*CustomView, error) {
+	err := cvs.client.requireAPIVersion(customViewsMinAPIVersion, "custom views")
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/customviews", cvs.client.SiteID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := cvs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query custom views")
+	}
+
+	resp := &queryCustomViewsResponse{}
+	err = cvs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CustomViews.CustomView, nil
+}
+
+func (cvs *customViewsService) Get(ctx context.Context, id string) (*CustomView, error) {
+	err := cvs.client.requireAPIVersion(customViewsMinAPIVersion, "custom views")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/customviews/%s", cvs.client.SiteID, id)
+	req, err := cvs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get custom view")
+	}
+
+	resp := &customViewResponse{}
+	err = cvs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CustomView, nil
+}
+
+func (cvs *customViewsService) Update(ctx context.Context, updateReq *UpdateCustomViewRequest) (*CustomView, error) {
+	err := cvs.client.requireAPIVersion(customViewsMinAPIVersion, "custom views")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/customviews/%s", cvs.client.SiteID, updateReq.ID)
+
+	payload := &updateCustomViewPayload{
+		Name: updateReq.Name,
+	}
+	if updateReq.OwnerID != "" {
+		payload.Owner = &resourceID{ID: updateReq.OwnerID}
+	}
+
+	request := struct {
+		CustomView *updateCustomViewPayload `json:"customView"`
+	}{
+		CustomView: payload,
+	}
+	req, err := cvs.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update custom view")
+	}
+
+	resp := &customViewResponse{}
+	err = cvs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CustomView, nil
+}
+
+func (cvs *customViewsService) Delete(ctx context.Context, id string) error {
+	err := cvs.client.requireAPIVersion(customViewsMinAPIVersion, "custom views")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/customviews/%s", cvs.client.SiteID, id)
+	req, err := cvs.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete custom view")
+	}
+	err = cvs.client.do(ctx, req, nil)
+	return err
+}
+
+// Download writes the definition of the custom view, a JSON document, to w.
+func (cvs *customViewsService) Download(ctx context.Context, id string, w io.Writer) error {
+	err := cvs.client.requireAPIVersion("3.21", "custom view download")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/customviews/%s/content", cvs.client.SiteID, id)
+	req, err := cvs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for download custom view")
+	}
+
+	_, err = cvs.client.download(ctx, req, w)
+	return err
+}
+
+// Publish creates a custom view on a workbook from a definition read from r.
+func (cvs *customViewsService) Publish(ctx context.Context, publishReq *PublishCustomViewRequest, r io.Reader) (*CustomView, error) {
+	err := cvs.client.requireAPIVersion("3.21", "custom view publish")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/customviews", cvs.client.SiteID)
+
+	payload := publishCustomViewPayload{
+		Name:     publishReq.Name,
+		Shared:   publishReq.Shared,
+		Workbook: resourceID{ID: publishReq.WorkbookID},
+	}
+	if publishReq.OwnerID != "" {
+		payload.Owner = &resourceID{ID: publishReq.OwnerID}
+	}
+
+	request := struct {
+		CustomView publishCustomViewPayload `json:"customView"`
+	}{
+		CustomView: payload,
+	}
+
+	req, err := cvs.client.newMultipartRequest(http.MethodPost, path, request, "tableau_customview", publishReq.FileName, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for publish custom view")
+	}
+
+	resp := &customViewResponse{}
+	err = cvs.client.send(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.CustomView, nil
+}
+
+// SetDefaultForUsers makes the custom view the default view of the given
+// users. It returns a result per user, as the call can partially succeed.
+func (cvs *customViewsService) SetDefaultForUsers(ctx context.Context, id string, userIDs []string) ([]*CustomViewDefaultResult, error) {
+	err := cvs.client.requireAPIVersion("3.21", "custom view user defaults")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/customviews/%s/default/users", cvs.client.SiteID, id)
+
+	users := make([]resourceID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		users = append(users, resourceID{ID: userID})
+	}
+	request := struct {
+		Users struct {
+			User []resourceID `json:"user"`
+		} `json:"users"`
+	}{}
+	request.Users.User = users
+
+	req, err := cvs.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for set custom view user defaults")
+	}
+
+	resp := &customViewDefaultResultsResponse{}
+	err = cvs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Results.Result, nil
+}
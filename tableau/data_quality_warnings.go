@@ -0,0 +1,203 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+)
+
+const dataQualityWarningsMinAPIVersion = "3.9"
+
+// DataQualityWarningContentType represents the type of content a data quality
+// warning can be attached to.
+type DataQualityWarningContentType string
+
+const (
+	DataQualityWarningContentDatabase   DataQualityWarningContentType = "database"
+	DataQualityWarningContentTable      DataQualityWarningContentType = "table"
+	DataQualityWarningContentDataSource DataQualityWarningContentType = "datasource"
+	DataQualityWarningContentFlow       DataQualityWarningContentType = "flow"
+)
+
+// DataQualityWarningType represents the kind of a data quality warning.
+type DataQualityWarningType string
+
+const (
+	DataQualityWarningTypeWarning       DataQualityWarningType = "WARNING"
+	DataQualityWarningTypeDeprecated    DataQualityWarningType = "DEPRECATED"
+	DataQualityWarningTypeStale         DataQualityWarningType = "STALE"
+	DataQualityWarningTypeSensitiveData DataQualityWarningType = "SENSITIVE_DATA"
+	DataQualityWarningTypeMaintenance   DataQualityWarningType = "MAINTENANCE"
+)
+
+// DataQualityWarning represents a data quality warning attached to a
+// database, table, data source or flow.
+type DataQualityWarning struct {
+	ID          string                        `json:"id"`
+	Type        DataQualityWarningType        `json:"type"`
+	Message     string                        `json:"message"`
+	IsActive    bool                          `json:"isActive"`
+	IsSevere    bool                          `json:"isSevere"`
+	ContentID   string                        `json:"contentId"`
+	ContentType DataQualityWarningContentType `json:"contentType"`
+	Owner       struct {
+		ID string `json:"id"`
+	} `json:"owner"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DataQualityWarningRequest encapsulates the request for adding or updating a
+// data quality warning.
+type DataQualityWarningRequest struct {
+	Type     DataQualityWarningType `json:"type,omitempty"`
+	Message  string                 `json:"message,omitempty"`
+	IsActive *bool                  `json:"isActive,omitempty"`
+	IsSevere *bool                  `json:"isSevere,omitempty"`
+}
+
+type dataQualityWarningResponse struct {
+	DataQualityWarning *DataQualityWarning `json:"dataQualityWarning"`
+}
+
+type queryDataQualityWarningsResponse struct {
+	DataQualityWarnings struct {
+		DataQualityWarning []*DataQualityWarning `json:"dataQualityWarning"`
+	} `json:"dataQualityWarningList"`
+}
+
+type dataQualityWarningsService struct {
+	client *Client
+}
+
+// Add adds a data quality warning to the content.
+func (dqws *dataQualityWarningsService) Add(ctx context.Context, contentType DataQualityWarningContentType, contentID string, addReq *DataQualityWarningRequest) (*DataQualityWarning, error) {
+	err := dqws.client.requireAPIVersion(dataQualityWarningsMinAPIVersion, "data quality warnings")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/dataQualityWarnings/%s/%s", dqws.client.SiteID, contentType, contentID)
+
+	request := struct {
+		DataQualityWarning *DataQualityWarningRequest `json:"dataQualityWarning"`
+	}{
+		DataQualityWarning: addReq,
+	}
+
+	req, err := dqws.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for add data quality warning")
+	}
+
+	resp := &dataQualityWarningResponse{}
+	err = dqws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DataQualityWarning, nil
+}
+
+// Query returns the data quality warnings of the content.
+func (dqws *dataQualityWarningsService) Query(ctx context.Context, contentType DataQualityWarningContentType, contentID string) ([]*DataQualityWarning, error) {
+	err := dqws.client.requireAPIVersion(dataQualityWarningsMinAPIVersion, "data quality warnings")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/dataQualityWarnings/%s/%s", dqws.client.SiteID, contentType, contentID)
+	req, err := dqws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query data quality warnings")
+	}
+
+	resp := &queryDataQualityWarningsResponse{}
+	err = dqws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.DataQualityWarnings.DataQualityWarning, nil
+}
+
+func (dqws *dataQualityWarningsService) Get(ctx context.Context, id string) (*DataQualityWarning, error) {
+	err := dqws.client.requireAPIVersion(dataQualityWarningsMinAPIVersion, "data quality warnings")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/dataQualityWarnings/%s", dqws.client.SiteID, id)
+	req, err := dqws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get data quality warning")
+	}
+
+	resp := &dataQualityWarningResponse{}
+	err = dqws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DataQualityWarning, nil
+}
+
+// Update updates the data quality warning with the given id. Empty fields of
+// updateReq are left unchanged on the server.
+func (dqws *dataQualityWarningsService) Update(ctx context.Context, id string, updateReq *DataQualityWarningRequest) (*DataQualityWarning, error) {
+	err := dqws.client.requireAPIVersion(dataQualityWarningsMinAPIVersion, "data quality warnings")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/dataQualityWarnings/%s", dqws.client.SiteID, id)
+
+	request := struct {
+		DataQualityWarning *DataQualityWarningRequest `json:"dataQualityWarning"`
+	}{
+		DataQualityWarning: updateReq,
+	}
+
+	req, err := dqws.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update data quality warning")
+	}
+
+	resp := &dataQualityWarningResponse{}
+	err = dqws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.DataQualityWarning, nil
+}
+
+func (dqws *dataQualityWarningsService) Delete(ctx context.Context, id string) error {
+	err := dqws.client.requireAPIVersion(dataQualityWarningsMinAPIVersion, "data quality warnings")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/dataQualityWarnings/%s", dqws.client.SiteID, id)
+	req, err := dqws.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete data quality warning")
+	}
+	err = dqws.client.do(ctx, req, nil)
+	return err
+}
+
+// DeleteAll deletes all the data quality warnings of the content.
+func (dqws *dataQualityWarningsService) DeleteAll(ctx context.Context, contentType DataQualityWarningContentType, contentID string) error {
+	err := dqws.client.requireAPIVersion(dataQualityWarningsMinAPIVersion, "data quality warnings")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/dataQualityWarnings/%s/%s", dqws.client.SiteID, contentType, contentID)
+	req, err := dqws.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete data quality warnings")
+	}
+	err = dqws.client.do(ctx, req, nil)
+	return err
+}
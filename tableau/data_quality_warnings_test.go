@@ -0,0 +1,62 @@
+package tableau
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDataQualityWarningsAdd(t *testing.T) {
+	tests := []struct {
+		contentType DataQualityWarningContentType
+		wantPath    string
+	}{
+		{DataQualityWarningContentDatabase, "/api/3.9/sites/site-id/dataQualityWarnings/database/content-id"},
+		{DataQualityWarningContentTable, "/api/3.9/sites/site-id/dataQualityWarnings/table/content-id"},
+		{DataQualityWarningContentDataSource, "/api/3.9/sites/site-id/dataQualityWarnings/datasource/content-id"},
+		{DataQualityWarningContentFlow, "/api/3.9/sites/site-id/dataQualityWarnings/flow/content-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.contentType), func(t *testing.T) {
+			c := qt.New(t)
+			client := newTestClient(t, "/api/3.9/sites/site-id/dataQualityWarnings/", func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, http.MethodPost)
+				c.Check(r.URL.Path, qt.Equals, tt.wantPath)
+				body, err := ioutil.ReadAll(r.Body)
+				c.Check(err, qt.IsNil)
+				c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+					"dataQualityWarning": map[string]interface{}{
+						"type":     "DEPRECATED",
+						"message":  "use sales_v2",
+						"isActive": true,
+					},
+				})
+				_, _ = w.Write([]byte(`{"dataQualityWarning": {"id": "dqw-id", "type": "DEPRECATED", "contentType": "` + string(tt.contentType) + `"}}`))
+			}, WithAPIVersion("3.9"))
+
+			active := true
+			dqw, err := client.DataQualityWarnings.Add(context.Background(), tt.contentType, "content-id", &DataQualityWarningRequest{
+				Type:     DataQualityWarningTypeDeprecated,
+				Message:  "use sales_v2",
+				IsActive: &active,
+			})
+			c.Assert(err, qt.IsNil)
+			c.Assert(dqw.ID, qt.Equals, "dqw-id")
+			c.Assert(dqw.ContentType, qt.Equals, tt.contentType)
+		})
+	}
+}
+
+func TestDataQualityWarningsRequireAPIVersion(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/dataQualityWarnings", func(w http.ResponseWriter, r *http.Request) {
+		c.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	_, err := client.DataQualityWarnings.Query(context.Background(), DataQualityWarningContentTable, "table-id")
+	c.Assert(err, qt.ErrorMatches, `data quality warnings requires REST API version 3.9 or later.*`)
+}
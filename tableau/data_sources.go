@@ -4,7 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
+	"io"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -18,23 +22,80 @@ type GetDataSourceRequest struct {
 	ID string
 }
 
+// UpdateDataSourceRequest encapsulates the request for updating a DataSource.
+// Empty fields are left unchanged on the server.
+type UpdateDataSourceRequest struct {
+	ID                string
+	Name              string
+	NewProjectID      string
+	OwnerID           string
+	IsCertified       *bool
+	CertificationNote string
+}
+
+// updateDataSourcePayload is the wire representation of UpdateDataSourceRequest.
+type updateDataSourcePayload struct {
+	Name              string      `json:"name,omitempty"`
+	IsCertified       *bool       `json:"isCertified,omitempty"`
+	CertificationNote string      `json:"certificationNote,omitempty"`
+	Project           *resourceID `json:"project,omitempty"`
+	Owner             *resourceID `json:"owner,omitempty"`
+}
+
+// resourceID references another resource by its ID in request bodies.
+type resourceID struct {
+	ID string `json:"id"`
+}
+
+// PublishDataSourceRequest encapsulates the request for publishing a data
+// source.
+type PublishDataSourceRequest struct {
+	Name        string
+	Description string
+	// FileName is the name of the uploaded file. Its extension, ".tds",
+	// ".tdsx", ".tde" or ".hyper", decides the type of the data source.
+	FileName  string
+	ProjectID string
+	// Overwrite replaces an existing data source with the same name.
+	Overwrite bool
+	// Append appends the uploaded extract to an existing data source.
+	Append                bool
+	ConnectionCredentials *ConnectionCredentials
+}
+
+// ConnectionCredentials are the credentials embedded in a published data
+// source or a connection. OAuth credentials have no password.
+type ConnectionCredentials struct {
+	Name     string `json:"name"`
+	Password string `json:"password,omitempty"`
+	Embed    bool   `json:"embed"`
+	OAuth    bool   `json:"oAuth,omitempty"`
+}
+
+type publishDataSourcePayload struct {
+	Name                  string                 `json:"name"`
+	Description           string                 `json:"description,omitempty"`
+	Project               resourceID             `json:"project"`
+	ConnectionCredentials *ConnectionCredentials `json:"connectionCredentials,omitempty"`
+}
+
 type dataSourcesResponse struct {
 	DataSource *DataSource `json:"dataSource"`
 }
 
 // DataSource represents a Tableau data source
 type DataSource struct {
-	ID                  string            `json:"id"`
-	Name                string            `json:"name"`
-	CertificationNote   string            `json:"CertificationNote"`
-	ContentUrl          string            `json:"contentUrl"`
-	EncryptExtracts     string            `json:"encryptExtracts"`
-	Description         string            `json:"description"`
-	WebpageUrl          string            `json:"webpageUrl"`
-	IsCertified         bool              `json:"isCertified"`
-	UseRemoteQueryAgent bool              `json:"useRemoteQueryAgent"`
-	Type                string            `json:"type"`
-	Tags                map[string]string `json:"tags"`
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	CertificationNote   string `json:"CertificationNote"`
+	ContentUrl          string `json:"contentUrl"`
+	EncryptExtracts     string `json:"encryptExtracts"`
+	Description         string `json:"description"`
+	WebpageUrl          string `json:"webpageUrl"`
+	IsCertified         bool   `json:"isCertified"`
+	UseRemoteQueryAgent bool   `json:"useRemoteQueryAgent"`
+	Type                string `json:"type"`
+	Tags                Tags   `json:"tags"`
 	Owner               struct {
 		ID string `json:"id"`
 	}
@@ -46,10 +107,39 @@ type DataSource struct {
 	UpdatedAt time.Time `json:"UpdatedAt"`
 }
 
+type queryDataSourcesResponse struct {
+	Pagination  Pagination
+	DataSources struct {
+		DataSource []*DataSource `json:"datasource"`
+	} `json:"datasources"`
+}
+
 type dataSourcesService struct {
 	client *Client
 }
 
+// Query returns a page of data sources along with the pagination details
+// needed to fetch the next pages.
+func (dss *dataSourcesService) Query(ctx context.Context, opts ...QueryOption) ([]*DataSource, *Pagination, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/datasources", dss.client.SiteID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := dss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query datasources")
+	}
+
+	resp := &queryDataSourcesResponse{}
+	err = dss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.DataSources.DataSource, &resp.Pagination, nil
+}
+
 func (dss *dataSourcesService) Get(ctx context.Context, getReq *GetDataSourceRequest) (*DataSource, error) {
 	path := fmt.Sprintf("sites/%s/datasources/%s", dss.client.SiteID, getReq.ID)
 	req, err := dss.client.newRequest(http.MethodGet, path, nil)
@@ -66,6 +156,160 @@ func (dss *dataSourcesService) Get(ctx context.Context, getReq *GetDataSourceReq
 	return ds.DataSource, nil
 }
 
+func (dss *dataSourcesService) Update(ctx context.Context, updateReq *UpdateDataSourceRequest) (*DataSource, error) {
+	path := fmt.Sprintf("sites/%s/datasources/%s", dss.client.SiteID, updateReq.ID)
+
+	payload := &updateDataSourcePayload{
+		Name:              updateReq.Name,
+		IsCertified:       updateReq.IsCertified,
+		CertificationNote: updateReq.CertificationNote,
+	}
+	if updateReq.NewProjectID != "" {
+		payload.Project = &resourceID{ID: updateReq.NewProjectID}
+	}
+	if updateReq.OwnerID != "" {
+		payload.Owner = &resourceID{ID: updateReq.OwnerID}
+	}
+
+	request := struct {
+		DataSource *updateDataSourcePayload `json:"datasource"`
+	}{
+		DataSource: payload,
+	}
+	req, err := dss.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update datasource")
+	}
+
+	ds := &dataSourcesResponse{}
+	err = dss.client.do(ctx, req, &ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return ds.DataSource, nil
+}
+
+// Publish uploads a data source read from r in a single request. Tableau
+// limits single request publishing to files of 64 MB.
+func (dss *dataSourcesService) Publish(ctx context.Context, publishReq *PublishDataSourceRequest, r io.Reader) (*DataSource, error) {
+	req, err := dss.newPublishRequest(publishReq, r, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := &dataSourcesResponse{}
+	err = dss.client.send(ctx, req, &ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return ds.DataSource, nil
+}
+
+// Append appends the extract read from r, a .hyper or .tde file, to the
+// published data source with the given id. The extract is sent in chunks
+// through a file upload session, so it isn't subject to the size limit of
+// Publish.
+func (dss *dataSourcesService) Append(ctx context.Context, id string, r io.Reader) (*DataSource, error) {
+	uploadSessionID, err := dss.client.FileUploads.Upload(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("uploadSessionId", uploadSessionID)
+	query.Set("action", "append")
+	path := fmt.Sprintf("sites/%s/datasources/%s?%s", dss.client.SiteID, id, query.Encode())
+
+	req, err := dss.client.newRequest(http.MethodPut, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for append datasource")
+	}
+
+	ds := &dataSourcesResponse{}
+	err = dss.client.send(ctx, req, &ds)
+	if err != nil {
+		return nil, err
+	}
+
+	return ds.DataSource, nil
+}
+
+// PublishAsJob uploads a data source read from r and lets the server process
+// it asynchronously. It returns the job processing the data source.
+func (dss *dataSourcesService) PublishAsJob(ctx context.Context, publishReq *PublishDataSourceRequest, r io.Reader) (*Job, error) {
+	req, err := dss.newPublishRequest(publishReq, r, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &jobResponse{}
+	err = dss.client.send(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+func (dss *dataSourcesService) newPublishRequest(publishReq *PublishDataSourceRequest, r io.Reader, asJob bool) (*http.Request, error) {
+	dataSourceType := strings.TrimPrefix(strings.ToLower(filepath.Ext(publishReq.FileName)), ".")
+	switch dataSourceType {
+	case "tds", "tdsx", "tde", "hyper":
+	default:
+		return nil, errors.Errorf("unsupported datasource file %q, expected a .tds, .tdsx, .tde or .hyper file", publishReq.FileName)
+	}
+
+	query := url.Values{}
+	query.Set("datasourceType", dataSourceType)
+	if publishReq.Overwrite {
+		query.Set("overwrite", "true")
+	}
+	if publishReq.Append {
+		query.Set("append", "true")
+	}
+	if asJob {
+		query.Set("asJob", "true")
+	}
+	path := fmt.Sprintf("sites/%s/datasources?%s", dss.client.SiteID, query.Encode())
+
+	request := struct {
+		DataSource publishDataSourcePayload `json:"datasource"`
+	}{
+		DataSource: publishDataSourcePayload{
+			Name:                  publishReq.Name,
+			Description:           publishReq.Description,
+			Project:               resourceID{ID: publishReq.ProjectID},
+			ConnectionCredentials: publishReq.ConnectionCredentials,
+		},
+	}
+
+	req, err := dss.client.newMultipartRequest(http.MethodPost, path, request, "tableau_datasource", publishReq.FileName, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for publish datasource")
+	}
+	return req, nil
+}
+
+// Download writes the content of the data source, a .tds, .tdsx or .hyper
+// file, to w. Without includeExtract the extract of the data source is left
+// out of the download.
+func (dss *dataSourcesService) Download(ctx context.Context, id string, w io.Writer, includeExtract bool) error {
+	path := fmt.Sprintf("sites/%s/datasources/%s/content", dss.client.SiteID, id)
+	if !includeExtract {
+		path += "?includeExtract=false"
+	}
+
+	req, err := dss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for download datasource")
+	}
+
+	_, err = dss.client.download(ctx, req, w)
+	return err
+}
+
 func (dss *dataSourcesService) Delete(ctx context.Context, delReq *DeleteDataSourceRequest) error {
 	path := fmt.Sprintf("sites/%s/datasources/%s", dss.client.SiteID, delReq.ID)
 	req, err := dss.client.newRequest(http.MethodDelete, path, nil)
@@ -75,3 +319,254 @@ func (dss *dataSourcesService) Delete(ctx context.Context, delReq *DeleteDataSou
 	err = dss.client.do(ctx, req, nil)
 	return err
 }
+
+// Connection represents a connection of a published data source or workbook.
+type Connection struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	ServerAddress string `json:"serverAddress"`
+	ServerPort    string `json:"serverPort"`
+	UserName      string `json:"userName"`
+	EmbedPassword bool   `json:"embedPassword"`
+}
+
+// UpdateConnectionRequest encapsulates the request for updating a connection.
+// Empty fields are left unchanged on the server.
+type UpdateConnectionRequest struct {
+	ServerAddress string `json:"serverAddress,omitempty"`
+	ServerPort    string `json:"serverPort,omitempty"`
+	UserName      string `json:"userName,omitempty"`
+	Password      string `json:"password,omitempty"`
+	EmbedPassword *bool  `json:"embedPassword,omitempty"`
+	// ConnectionCredentials embeds credentials in the connection, i.e; a
+	// saved OAuth credential with OAuth set and Name being its username.
+	ConnectionCredentials *ConnectionCredentials `json:"connectionCredentials,omitempty"`
+}
+
+type connectionsResponse struct {
+	Connections struct {
+		Connection []*Connection `json:"connection"`
+	} `json:"connections"`
+}
+
+type connectionResponse struct {
+	Connection *Connection `json:"connection"`
+}
+
+// ListConnections returns the connections of the data source.
+//
+// Deprecated: use Connections instead.
+func (dss *dataSourcesService) ListConnections(ctx context.Context, id string) ([]*Connection, error) {
+	return dss.Connections(ctx, id)
+}
+
+func (dss *dataSourcesService) Connections(ctx context.Context, id string) ([]*Connection, error) {
+	path := fmt.Sprintf("sites/%s/datasources/%s/connections", dss.client.SiteID, id)
+	req, err := dss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for list datasource connections")
+	}
+
+	resp := &connectionsResponse{}
+	err = dss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Connections.Connection, nil
+}
+
+func (dss *dataSourcesService) UpdateConnection(ctx context.Context, dataSourceID, connectionID string, updateReq *UpdateConnectionRequest) (*Connection, error) {
+	path := fmt.Sprintf("sites/%s/datasources/%s/connections/%s", dss.client.SiteID, dataSourceID, connectionID)
+
+	request := struct {
+		Connection *UpdateConnectionRequest `json:"connection"`
+	}{
+		Connection: updateReq,
+	}
+	req, err := dss.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update datasource connection")
+	}
+
+	resp := &connectionResponse{}
+	err = dss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Connection, nil
+}
+
+// Revision represents a published revision of a data source or workbook.
+type Revision struct {
+	RevisionNumber int       `json:"revisionNumber,string"`
+	PublishedAt    time.Time `json:"publishedAt"`
+	Deleted        bool      `json:"deleted"`
+	Current        bool      `json:"current"`
+	SizeInBytes    int64     `json:"sizeInBytes,string"`
+	Publisher      struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"publisher"`
+}
+
+type revisionsResponse struct {
+	Revisions struct {
+		Revision []*Revision `json:"revision"`
+	} `json:"revisions"`
+}
+
+func (dss *dataSourcesService) ListRevisions(ctx context.Context, id string) ([]*Revision, error) {
+	path := fmt.Sprintf("sites/%s/datasources/%s/revisions", dss.client.SiteID, id)
+	req, err := dss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for list datasource revisions")
+	}
+
+	resp := &revisionsResponse{}
+	err = dss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Revisions.Revision, nil
+}
+
+// DownloadRevision returns the content of the given revision of a data
+// source. The caller must close the returned reader.
+func (dss *dataSourcesService) DownloadRevision(ctx context.Context, id string, revision int) (io.ReadCloser, error) {
+	path := fmt.Sprintf("sites/%s/datasources/%s/revisions/%d/content", dss.client.SiteID, id, revision)
+	req, err := dss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for download datasource revision")
+	}
+
+	res, err := dss.client.doStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Body, nil
+}
+
+func (dss *dataSourcesService) DeleteRevision(ctx context.Context, id string, revision int) error {
+	path := fmt.Sprintf("sites/%s/datasources/%s/revisions/%d", dss.client.SiteID, id, revision)
+	req, err := dss.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete datasource revision")
+	}
+	err = dss.client.do(ctx, req, nil)
+	return err
+}
+
+// RefreshNow starts an extract refresh of the data source and returns the job
+// running it.
+func (dss *dataSourcesService) RefreshNow(ctx context.Context, id string) (*Job, error) {
+	path := fmt.Sprintf("sites/%s/datasources/%s/refresh", dss.client.SiteID, id)
+	req, err := dss.client.newRequest(http.MethodPost, path, struct{}{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for refresh datasource")
+	}
+
+	resp := &jobResponse{}
+	err = dss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// CreateExtract converts a live data source to an extract, optionally
+// encrypted, and returns the job creating it.
+func (dss *dataSourcesService) CreateExtract(ctx context.Context, id string, encrypt bool) (*Job, error) {
+	err := dss.client.requireAPIVersion("3.5", "datasource extract creation")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/datasources/%s/createExtract", dss.client.SiteID, id)
+	if encrypt {
+		path += "?encrypt=true"
+	}
+
+	req, err := dss.client.newRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for create datasource extract")
+	}
+
+	resp := &jobResponse{}
+	err = dss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// DeleteExtract removes the extract of a data source, turning it back into a
+// live connection.
+func (dss *dataSourcesService) DeleteExtract(ctx context.Context, id string) error {
+	err := dss.client.requireAPIVersion("3.5", "datasource extract deletion")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/datasources/%s/deleteExtract", dss.client.SiteID, id)
+	req, err := dss.client.newRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete datasource extract")
+	}
+	err = dss.client.do(ctx, req, nil)
+	return err
+}
+
+// DeleteExtractAsJob removes the extract of a data source like DeleteExtract,
+// but lets the server process the removal asynchronously. It returns the job
+// removing the extract.
+func (dss *dataSourcesService) DeleteExtractAsJob(ctx context.Context, id string) (*Job, error) {
+	err := dss.client.requireAPIVersion("3.5", "datasource extract deletion")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/datasources/%s/deleteExtract?asJob=true", dss.client.SiteID, id)
+	req, err := dss.client.newRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for delete datasource extract")
+	}
+
+	resp := &jobResponse{}
+	err = dss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// AddTags adds tags to the data source and returns the tags that were added.
+func (dss *dataSourcesService) AddTags(ctx context.Context, id string, tags []string) ([]*Tag, error) {
+	return dss.client.addTags(ctx, fmt.Sprintf("sites/%s/datasources/%s/tags", dss.client.SiteID, id), tags)
+}
+
+func (dss *dataSourcesService) DeleteTag(ctx context.Context, id, tag string) error {
+	return dss.client.deleteTag(ctx, fmt.Sprintf("sites/%s/datasources/%s/tags", dss.client.SiteID, id), tag)
+}
+
+// Permissions returns the explicit permissions set on the data source.
+func (dss *dataSourcesService) Permissions(ctx context.Context, id string) (*Permissions, error) {
+	return dss.client.permissions(ctx, fmt.Sprintf("sites/%s/datasources/%s/permissions", dss.client.SiteID, id))
+}
+
+// AddPermissions adds the rules in permissions to the data source and returns the
+// rules that were added.
+func (dss *dataSourcesService) AddPermissions(ctx context.Context, id string, permissions *Permissions) (*Permissions, error) {
+	return dss.client.addPermissions(ctx, fmt.Sprintf("sites/%s/datasources/%s/permissions", dss.client.SiteID, id), permissions)
+}
+
+// DeletePermission removes the capabilities of rule from the data source.
+func (dss *dataSourcesService) DeletePermission(ctx context.Context, id string, rule *GranteeCapabilities) error {
+	return dss.client.deletePermission(ctx, fmt.Sprintf("sites/%s/datasources/%s/permissions", dss.client.SiteID, id), rule)
+}
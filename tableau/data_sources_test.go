@@ -0,0 +1,230 @@
+package tableau
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDataSourcesUpdate(t *testing.T) {
+	certified := false
+
+	tests := []struct {
+		desc     string
+		req      *UpdateDataSourceRequest
+		wantBody map[string]interface{}
+	}{
+		{
+			desc: "omits unset fields",
+			req: &UpdateDataSourceRequest{
+				ID:           "ds-id",
+				NewProjectID: "project-id",
+				IsCertified:  &certified,
+			},
+			wantBody: map[string]interface{}{
+				"isCertified": false,
+				"project":     map[string]interface{}{"id": "project-id"},
+			},
+		},
+		{
+			desc: "renames and changes owner",
+			req: &UpdateDataSourceRequest{
+				ID:                "ds-id",
+				Name:              "sales",
+				OwnerID:           "owner-id",
+				CertificationNote: "reviewed",
+			},
+			wantBody: map[string]interface{}{
+				"name":              "sales",
+				"certificationNote": "reviewed",
+				"owner":             map[string]interface{}{"id": "owner-id"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			client := newTestClient(t, "/api/3.4/sites/site-id/datasources/ds-id", func(w http.ResponseWriter, r *http.Request) {
+				c.Assert(r.Method, qt.Equals, http.MethodPut)
+
+				var body map[string]map[string]interface{}
+				err := json.NewDecoder(r.Body).Decode(&body)
+				c.Assert(err, qt.IsNil)
+				c.Assert(body["datasource"], qt.DeepEquals, tt.wantBody)
+
+				_, _ = w.Write([]byte(`{"datasource": {"id": "ds-id", "name": "sales"}}`))
+			})
+
+			ds, err := client.DataSources.Update(context.Background(), tt.req)
+			c.Assert(err, qt.IsNil)
+			c.Assert(ds.Name, qt.Equals, "sales")
+		})
+	}
+}
+
+func TestDataSourcesConnections(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/datasources/ds-id/connections", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/3.4/sites/site-id/datasources/ds-id/connections":
+			_, _ = w.Write([]byte(`{"connections": {"connection": [
+				{"id": "conn-id", "type": "snowflake", "serverAddress": "acme.snowflakecomputing.com", "serverPort": "443", "userName": "etl", "embedPassword": true}
+			]}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/3.4/sites/site-id/datasources/ds-id/connections/conn-id":
+			var body map[string]map[string]interface{}
+			err := json.NewDecoder(r.Body).Decode(&body)
+			c.Check(err, qt.IsNil)
+			c.Check(body["connection"], qt.DeepEquals, map[string]interface{}{
+				"serverAddress": "prod.snowflakecomputing.com",
+				"embedPassword": true,
+				"connectionCredentials": map[string]interface{}{
+					"name":  "etl@acme.com",
+					"embed": true,
+					"oAuth": true,
+				},
+			})
+			_, _ = w.Write([]byte(`{"connection": {"id": "conn-id", "serverAddress": "prod.snowflakecomputing.com"}}`))
+		default:
+			c.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	conns, err := client.DataSources.Connections(context.Background(), "ds-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(conns, qt.HasLen, 1)
+	c.Assert(conns[0].ServerPort, qt.Equals, "443")
+	c.Assert(conns[0].EmbedPassword, qt.IsTrue)
+
+	embed := true
+	conn, err := client.DataSources.UpdateConnection(context.Background(), "ds-id", "conn-id", &UpdateConnectionRequest{
+		ServerAddress: "prod.snowflakecomputing.com",
+		EmbedPassword: &embed,
+		ConnectionCredentials: &ConnectionCredentials{
+			Name:  "etl@acme.com",
+			Embed: true,
+			OAuth: true,
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(conn.ServerAddress, qt.Equals, "prod.snowflakecomputing.com")
+}
+
+func TestDataSourcesRevisions(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/datasources/ds-id/revisions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/3.4/sites/site-id/datasources/ds-id/revisions":
+			_, _ = w.Write([]byte(`{"revisions": {"revision": [
+				{"revisionNumber": "1", "current": false, "deleted": false, "publisher": {"id": "user-id", "name": "admin"}},
+				{"revisionNumber": "2", "current": true, "deleted": false, "publisher": {"id": "user-id", "name": "admin"}}
+			]}}`))
+		case "/api/3.4/sites/site-id/datasources/ds-id/revisions/1/content":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("revision 1"))
+		default:
+			c.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	revisions, err := client.DataSources.ListRevisions(context.Background(), "ds-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(revisions, qt.HasLen, 2)
+	c.Assert(revisions[1].RevisionNumber, qt.Equals, 2)
+	c.Assert(revisions[1].Current, qt.IsTrue)
+	c.Assert(revisions[1].Publisher.Name, qt.Equals, "admin")
+
+	rc, err := client.DataSources.DownloadRevision(context.Background(), "ds-id", 1)
+	c.Assert(err, qt.IsNil)
+	defer rc.Close()
+	content, err := ioutil.ReadAll(rc)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(content), qt.Equals, "revision 1")
+}
+
+func TestDataSourcesExtract(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.5/sites/site-id/datasources/ds-id", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPost)
+		switch r.URL.Path {
+		case "/api/3.5/sites/site-id/datasources/ds-id/createExtract":
+			c.Check(r.URL.Query().Get("encrypt"), qt.Equals, "true")
+			_, _ = w.Write([]byte(`{"job": {"id": "create-job-id", "type": "RefreshExtract"}}`))
+		case "/api/3.5/sites/site-id/datasources/ds-id/deleteExtract":
+			c.Check(r.URL.Query().Get("asJob"), qt.Equals, "true")
+			_, _ = w.Write([]byte(`{"job": {"id": "delete-job-id", "type": "DeleteExtract"}}`))
+		default:
+			c.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}, WithAPIVersion("3.5"))
+
+	job, err := client.DataSources.CreateExtract(context.Background(), "ds-id", true)
+	c.Assert(err, qt.IsNil)
+	c.Assert(job.ID, qt.Equals, "create-job-id")
+
+	job, err = client.DataSources.DeleteExtractAsJob(context.Background(), "ds-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(job.ID, qt.Equals, "delete-job-id")
+
+	oldClient := newTestClient(t, "/api/3.4/sites/site-id/datasources", func(w http.ResponseWriter, r *http.Request) {
+		c.Errorf("unexpected request to %s", r.URL.Path)
+	})
+	err = oldClient.DataSources.DeleteExtract(context.Background(), "ds-id")
+	c.Assert(err, qt.ErrorMatches, `datasource extract deletion requires REST API version 3.5 or later.*`)
+}
+
+func TestDataSourcesAppend(t *testing.T) {
+	c := qt.New(t)
+
+	var uploaded []byte
+	appended := false
+	client := newTestClient(t, "/api/3.4/sites/site-id/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/3.4/sites/site-id/fileUploads":
+			c.Check(r.Method, qt.Equals, http.MethodPost)
+			_, _ = w.Write([]byte(`{"fileUpload": {"uploadSessionId": "session-id", "fileSize": "0"}}`))
+		case "/api/3.4/sites/site-id/fileUploads/session-id":
+			c.Check(r.Method, qt.Equals, http.MethodPut)
+			_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			c.Assert(err, qt.IsNil)
+			mr := multipart.NewReader(r.Body, params["boundary"])
+			for {
+				part, err := mr.NextPart()
+				if err == io.EOF {
+					break
+				}
+				c.Assert(err, qt.IsNil)
+				if part.Header.Get("Content-Disposition") == `name="tableau_file"; filename="file"` {
+					chunk, err := ioutil.ReadAll(part)
+					c.Assert(err, qt.IsNil)
+					uploaded = append(uploaded, chunk...)
+				}
+			}
+			_, _ = w.Write([]byte(`{"fileUpload": {"uploadSessionId": "session-id"}}`))
+		case "/api/3.4/sites/site-id/datasources/ds-id":
+			c.Check(r.Method, qt.Equals, http.MethodPut)
+			c.Check(r.URL.Query().Get("uploadSessionId"), qt.Equals, "session-id")
+			c.Check(r.URL.Query().Get("action"), qt.Equals, "append")
+			appended = true
+			_, _ = w.Write([]byte(`{"datasource": {"id": "ds-id"}}`))
+		case "/api/3.4/sites/site-id/datasources":
+			c.Errorf("unexpected publish request %s %s", r.Method, r.URL.String())
+		default:
+			c.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	ds, err := client.DataSources.Append(context.Background(), "ds-id", strings.NewReader("rows"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(ds.ID, qt.Equals, "ds-id")
+	c.Assert(appended, qt.IsTrue)
+	c.Assert(string(uploaded), qt.Equals, "rows")
+}
@@ -0,0 +1,165 @@
+package tableau
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"sort"
+)
+
+// PermissionRules holds the permission rules that apply to a user on a piece
+// of content, as fetched with the Permissions and GetDefaultPermissions
+// methods and the Users.Groups method.
+type PermissionRules struct {
+	UserID string
+	// GroupIDs are the ids of the groups the user is a member of, including
+	// the All Users group.
+	GroupIDs []string
+	// Content holds the permissions set on the content itself.
+	Content *Permissions
+	// ProjectDefaults holds the default permissions of the content's project
+	// for the content type. They replace Content when Locked is set.
+	ProjectDefaults *Permissions
+	// Locked is set when the content's project locks permissions to the
+	// project, i.e; its content permissions are LockedToProject.
+	Locked bool
+	// Owner is set when the user owns the content.
+	Owner bool
+	// ProjectLeader is set when the user leads the content's project.
+	ProjectLeader bool
+	// SiteAdmin is set when the user is a site or server administrator.
+	SiteAdmin bool
+}
+
+// EffectivePermissions is the outcome of evaluating PermissionRules.
+type EffectivePermissions struct {
+	// All is set when the user holds every capability regardless of the
+	// rules, as owners, project leaders and administrators do.
+	All bool
+	// Capabilities maps each capability named in the applicable rules to its
+	// effective mode.
+	Capabilities map[CapabilityName]CapabilityMode
+}
+
+// Allowed reports whether the user holds the capability. Capabilities no
+// rule mentions are not granted.
+func (ep *EffectivePermissions) Allowed(name CapabilityName) bool {
+	return ep.All || ep.Capabilities[name] == CapabilityModeAllow
+}
+
+// ResolveEffectivePermissions computes the effective permissions of a user
+// on a piece of content the way Tableau evaluates them:
+//
+//   - owners, project leaders and administrators hold every capability
+//   - a rule on the user wins over rules on its groups
+//   - among rules on the same kind of grantee, Deny wins over Allow
+//   - capabilities no rule mentions are not granted
+func ResolveEffectivePermissions(rules *PermissionRules) *EffectivePermissions {
+	if rules.Owner || rules.ProjectLeader || rules.SiteAdmin {
+		return &EffectivePermissions{All: true}
+	}
+
+	permissions := rules.Content
+	if rules.Locked {
+		permissions = rules.ProjectDefaults
+	}
+
+	groups := make(map[string]bool, len(rules.GroupIDs))
+	for _, id := range rules.GroupIDs {
+		groups[id] = true
+	}
+
+	userModes := map[CapabilityName]CapabilityMode{}
+	groupModes := map[CapabilityName]CapabilityMode{}
+	if permissions != nil {
+		for _, rule := range permissions.GranteeCapabilities {
+			var modes map[CapabilityName]CapabilityMode
+			switch {
+			case rule.User != nil && rule.User.ID == rules.UserID:
+				modes = userModes
+			case rule.Group != nil && groups[rule.Group.ID]:
+				modes = groupModes
+			default:
+				continue
+			}
+			for _, c := range rule.Capabilities.Capability {
+				if modes[c.Name] != CapabilityModeDeny {
+					modes[c.Name] = c.Mode
+				}
+			}
+		}
+	}
+
+	for name, mode := range userModes {
+		groupModes[name] = mode
+	}
+	return &EffectivePermissions{Capabilities: groupModes}
+}
+
+// usersWithAccess returns the users granted capability by the rules in
+// permissions, expanding group grantees to their members. Owners, project
+// leaders and administrators are only included when a rule grants them the
+// capability.
+func (c *Client) usersWithAccess(ctx context.Context, permissions *Permissions, capability CapabilityName) ([]*User, error) {
+	if permissions == nil {
+		return nil, nil
+	}
+
+	users := map[string]*User{}
+	groupIDs := map[string][]string{}
+
+	for _, rule := range permissions.GranteeCapabilities {
+		switch {
+		case rule.User != nil:
+			if _, ok := users[rule.User.ID]; !ok {
+				users[rule.User.ID] = nil
+			}
+		case rule.Group != nil:
+			members, err := c.groupMembers(ctx, rule.Group.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, u := range members {
+				users[u.ID] = u
+				groupIDs[u.ID] = append(groupIDs[u.ID], rule.Group.ID)
+			}
+		}
+	}
+
+	var granted []*User
+	for id, u := range users {
+		effective := ResolveEffectivePermissions(&PermissionRules{
+			UserID:   id,
+			GroupIDs: groupIDs[id],
+			Content:  permissions,
+		})
+		if !effective.Allowed(capability) {
+			continue
+		}
+		if u == nil {
+			var err error
+			u, err = c.Users.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+		}
+		granted = append(granted, u)
+	}
+
+	sort.Slice(granted, func(i, j int) bool { return granted[i].Name < granted[j].Name })
+	return granted, nil
+}
+
+// groupMembers returns all the users of the group, fetching every page.
+func (c *Client) groupMembers(ctx context.Context, groupID string) ([]*User, error) {
+	var members []*User
+	for page := 1; ; page++ {
+		users, pagination, err := c.Groups.Users(ctx, groupID, WithPageSize(1000), WithPageNumber(page))
+		if err != nil {
+			return nil, errors.Wrapf(err, "error querying users of group %s", groupID)
+		}
+		members = append(members, users...)
+		if len(users) == 0 || pagination.PageNumber*pagination.PageSize >= pagination.TotalAvailable {
+			return members, nil
+		}
+	}
+}
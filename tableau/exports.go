@@ -0,0 +1,174 @@
+package tableau
+
+import (
+	"bytes"
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ImageResolution represents the resolution of an exported image.
+type ImageResolution string
+
+const (
+	ImageResolutionHigh ImageResolution = "high"
+)
+
+// PDFPageType represents the paper size of an exported PDF.
+type PDFPageType string
+
+const (
+	PDFPageTypeA3        PDFPageType = "A3"
+	PDFPageTypeA4        PDFPageType = "A4"
+	PDFPageTypeA5        PDFPageType = "A5"
+	PDFPageTypeB5        PDFPageType = "B5"
+	PDFPageTypeExecutive PDFPageType = "Executive"
+	PDFPageTypeFolio     PDFPageType = "Folio"
+	PDFPageTypeLedger    PDFPageType = "Ledger"
+	PDFPageTypeLegal     PDFPageType = "Legal"
+	PDFPageTypeLetter    PDFPageType = "Letter"
+	PDFPageTypeNote      PDFPageType = "Note"
+	PDFPageTypeQuarto    PDFPageType = "Quarto"
+	PDFPageTypeTabloid   PDFPageType = "Tabloid"
+)
+
+// PDFOrientation represents the page orientation of an exported PDF.
+type PDFOrientation string
+
+const (
+	PDFOrientationPortrait  PDFOrientation = "Portrait"
+	PDFOrientationLandscape PDFOrientation = "Landscape"
+)
+
+// ExportOptions are options for exporting views and workbooks.
+type ExportOptions struct {
+	URLValues *url.Values
+}
+
+type ExportOption func(*ExportOptions) error
+
+// applyExportOptions appends the URL parameters set by opts to path.
+func applyExportOptions(path string, opts []ExportOption) (string, error) {
+	exportOpts := &ExportOptions{
+		URLValues: &url.Values{},
+	}
+
+	for _, opt := range opts {
+		err := opt(exportOpts)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if vals := exportOpts.URLValues.Encode(); vals != "" {
+		path += "?" + vals
+	}
+	return path, nil
+}
+
+// WithImageResolution returns an ExportOption that sets the "resolution" URL
+// parameter.
+func WithImageResolution(resolution ImageResolution) ExportOption {
+	return func(opt *ExportOptions) error {
+		if resolution != "" {
+			opt.URLValues.Set("resolution", string(resolution))
+		}
+		return nil
+	}
+}
+
+// WithPDFPageType returns an ExportOption that sets the "type" URL parameter.
+func WithPDFPageType(pageType PDFPageType) ExportOption {
+	return func(opt *ExportOptions) error {
+		if pageType != "" {
+			opt.URLValues.Set("type", string(pageType))
+		}
+		return nil
+	}
+}
+
+// WithPDFOrientation returns an ExportOption that sets the "orientation" URL
+// parameter.
+func WithPDFOrientation(orientation PDFOrientation) ExportOption {
+	return func(opt *ExportOptions) error {
+		if orientation != "" {
+			opt.URLValues.Set("orientation", string(orientation))
+		}
+		return nil
+	}
+}
+
+// WithViewFilter returns an ExportOption that filters the exported view by
+// setting the "vf_<field>" URL parameter.
+func WithViewFilter(field, value string) ExportOption {
+	return func(opt *ExportOptions) error {
+		if field == "" {
+			return errors.New("view filter field must not be empty")
+		}
+		opt.URLValues.Set("vf_"+field, value)
+		return nil
+	}
+}
+
+// WithMaxAge returns an ExportOption that sets the "maxAge" URL parameter, the
+// number of minutes a cached export may be served for.
+func WithMaxAge(minutes int) ExportOption {
+	return func(opt *ExportOptions) error {
+		if minutes > 0 {
+			opt.URLValues.Set("maxAge", strconv.Itoa(minutes))
+		}
+		return nil
+	}
+}
+
+// export fetches the exported content at path. It returns the content along
+// with the Content-Type reported by the server.
+func (c *Client) export(ctx context.Context, path string, opts []ExportOption) ([]byte, string, error) {
+	buf := new(bytes.Buffer)
+	contentType, err := c.exportTo(ctx, path, buf, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), contentType, nil
+}
+
+// exportTo streams the exported content at path to w. It returns the
+// Content-Type reported by the server.
+func (c *Client) exportTo(ctx context.Context, path string, w io.Writer, opts []ExportOption) (string, error) {
+	path, err := applyExportOptions(path, opts)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := c.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "error creating request for export")
+	}
+
+	return c.download(ctx, req, w)
+}
+
+// exportStream requests the exported content at path and returns the unread
+// response body. The caller must close it.
+func (c *Client) exportStream(ctx context.Context, path string, opts []ExportOption) (io.ReadCloser, error) {
+	path, err := applyExportOptions(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for export")
+	}
+
+	res, err := c.doStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Body, nil
+}
@@ -0,0 +1,187 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+// ExtractRefreshTask represents a scheduled extract refresh of a workbook or
+// a data source. Exactly one of Workbook and DataSource is set.
+type ExtractRefreshTask struct {
+	ID                     string    `json:"id"`
+	Priority               int       `json:"priority,string"`
+	ConsecutiveFailedCount int       `json:"consecutiveFailedCount,string"`
+	Type                   string    `json:"type"`
+	Schedule               *Schedule `json:"schedule"`
+	Workbook               *struct {
+		ID string `json:"id"`
+	} `json:"workbook"`
+	DataSource *struct {
+		ID string `json:"id"`
+	} `json:"datasource"`
+}
+
+// CreateExtractRefreshTaskRequest encapsulates the request for creating an
+// extract refresh task on Tableau Cloud. Exactly one of WorkbookID and
+// DataSourceID must be set.
+type CreateExtractRefreshTaskRequest struct {
+	WorkbookID   string
+	DataSourceID string
+	// Type is either "FullRefresh" or "IncrementalRefresh".
+	Type     string
+	Schedule *TaskSchedule
+}
+
+type extractRefreshTaskPayload struct {
+	ExtractRefresh *ExtractRefreshTask `json:"extractRefresh"`
+}
+
+type extractRefreshTaskResponse struct {
+	Task *extractRefreshTaskPayload `json:"task"`
+}
+
+type queryExtractRefreshTasksResponse struct {
+	Tasks struct {
+		Task []*extractRefreshTaskPayload `json:"task"`
+	} `json:"tasks"`
+}
+
+type extractRefreshTasksService struct {
+	client *Client
+}
+
+func (erts *extractRefreshTasksService) Query(ctx context.Context) ([]*ExtractRefreshTask, error) {
+	path := fmt.Sprintf("sites/%s/tasks/extractRefreshes", erts.client.SiteID)
+	req, err := erts.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query extract refresh tasks")
+	}
+
+	resp := &queryExtractRefreshTasksResponse{}
+	err = erts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*ExtractRefreshTask, 0, len(resp.Tasks.Task))
+	for _, t := range resp.Tasks.Task {
+		tasks = append(tasks, t.ExtractRefresh)
+	}
+	return tasks, nil
+}
+
+func (erts *extractRefreshTasksService) Get(ctx context.Context, id string) (*ExtractRefreshTask, error) {
+	path := fmt.Sprintf("sites/%s/tasks/extractRefreshes/%s", erts.client.SiteID, id)
+	req, err := erts.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get extract refresh task")
+	}
+
+	resp := &extractRefreshTaskResponse{}
+	err = erts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Task.ExtractRefresh, nil
+}
+
+func (erts *extractRefreshTasksService) Delete(ctx context.Context, id string) error {
+	path := fmt.Sprintf("sites/%s/tasks/extractRefreshes/%s", erts.client.SiteID, id)
+	req, err := erts.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete extract refresh task")
+	}
+	err = erts.client.do(ctx, req, nil)
+	return err
+}
+
+// RunNow runs the extract refresh task outside of its schedule and returns
+// the job running it.
+func (erts *extractRefreshTasksService) RunNow(ctx context.Context, id string) (*Job, error) {
+	path := fmt.Sprintf("sites/%s/tasks/extractRefreshes/%s/runNow", erts.client.SiteID, id)
+	req, err := erts.client.newRequest(http.MethodPost, path, struct{}{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for run extract refresh task")
+	}
+
+	resp := &jobResponse{}
+	err = erts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Job, nil
+}
+
+// Create creates an extract refresh task with an embedded schedule. It is
+// only supported by Tableau Cloud; on Tableau Server add content to a server
+// schedule with Schedules.AddWorkbook or Schedules.AddDataSource instead.
+func (erts *extractRefreshTasksService) Create(ctx context.Context, createReq *CreateExtractRefreshTaskRequest) (*ExtractRefreshTask, error) {
+	err := erts.client.requireAPIVersion("3.20", "extract refresh task schedules")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/tasks/extractRefreshes", erts.client.SiteID)
+
+	request := struct {
+		ExtractRefresh struct {
+			Type       string      `json:"type"`
+			Workbook   *resourceID `json:"workbook,omitempty"`
+			DataSource *resourceID `json:"datasource,omitempty"`
+		} `json:"extractRefresh"`
+		Schedule *TaskSchedule `json:"schedule"`
+	}{
+		Schedule: createReq.Schedule,
+	}
+	request.ExtractRefresh.Type = createReq.Type
+	if createReq.WorkbookID != "" {
+		request.ExtractRefresh.Workbook = &resourceID{ID: createReq.WorkbookID}
+	}
+	if createReq.DataSourceID != "" {
+		request.ExtractRefresh.DataSource = &resourceID{ID: createReq.DataSourceID}
+	}
+
+	req, err := erts.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for create extract refresh task")
+	}
+
+	resp := &extractRefreshTaskPayload{}
+	err = erts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ExtractRefresh, nil
+}
+
+// UpdateSchedule replaces the embedded schedule of a Tableau Cloud extract
+// refresh task.
+func (erts *extractRefreshTasksService) UpdateSchedule(ctx context.Context, id string, schedule *TaskSchedule) (*ExtractRefreshTask, error) {
+	err := erts.client.requireAPIVersion("3.20", "extract refresh task schedules")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/tasks/extractRefreshes/%s", erts.client.SiteID, id)
+
+	request := struct {
+		Schedule *TaskSchedule `json:"schedule"`
+	}{
+		Schedule: schedule,
+	}
+
+	req, err := erts.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update extract refresh task")
+	}
+
+	resp := &extractRefreshTaskPayload{}
+	err = erts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ExtractRefresh, nil
+}
@@ -0,0 +1,75 @@
+package tableau
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestExtractRefreshTasks(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/tasks/extractRefreshes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/3.4/sites/site-id/tasks/extractRefreshes":
+			c.Check(r.Method, qt.Equals, http.MethodGet)
+			_, _ = w.Write([]byte(`{"tasks": {"task": [
+				{"extractRefresh": {"id": "task-id", "priority": "50", "type": "RefreshExtractTask",
+					"schedule": {"id": "schedule-id", "name": "Nightly"}, "workbook": {"id": "workbook-id"}}}
+			]}}`))
+		case "/api/3.4/sites/site-id/tasks/extractRefreshes/task-id/runNow":
+			c.Check(r.Method, qt.Equals, http.MethodPost)
+			_, _ = w.Write([]byte(`{"job": {"id": "job-id", "type": "RefreshExtract"}}`))
+		default:
+			c.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	tasks, err := client.ExtractRefreshTasks.Query(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(tasks, qt.HasLen, 1)
+	c.Assert(tasks[0].Priority, qt.Equals, 50)
+	c.Assert(tasks[0].Schedule.Name, qt.Equals, "Nightly")
+	c.Assert(tasks[0].Workbook.ID, qt.Equals, "workbook-id")
+	c.Assert(tasks[0].DataSource, qt.IsNil)
+
+	job, err := client.ExtractRefreshTasks.RunNow(context.Background(), "task-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(job.ID, qt.Equals, "job-id")
+}
+
+func TestExtractRefreshTasksCreate(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.20/sites/site-id/tasks/extractRefreshes", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPost)
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+			"extractRefresh": map[string]interface{}{
+				"type":       "FullRefresh",
+				"datasource": map[string]interface{}{"id": "ds-id"},
+			},
+			"schedule": map[string]interface{}{
+				"frequency": "Daily",
+				"frequencyDetails": map[string]interface{}{
+					"start": "02:00:00",
+					"intervals": map[string]interface{}{
+						"interval": []interface{}{map[string]interface{}{"hours": "24"}},
+					},
+				},
+			},
+		})
+		_, _ = w.Write([]byte(`{"extractRefresh": {"id": "task-id", "type": "FullRefresh", "datasource": {"id": "ds-id"}}}`))
+	}, WithAPIVersion("3.20"))
+
+	task, err := client.ExtractRefreshTasks.Create(context.Background(), &CreateExtractRefreshTaskRequest{
+		DataSourceID: "ds-id",
+		Type:         "FullRefresh",
+		Schedule:     DailySchedule("02:00:00"),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(task.ID, qt.Equals, "task-id")
+	c.Assert(task.DataSource.ID, qt.Equals, "ds-id")
+}
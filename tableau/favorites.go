@@ -0,0 +1,137 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+// FavoriteKind represents the type of content a favorite points to.
+type FavoriteKind string
+
+const (
+	FavoriteKindWorkbook   FavoriteKind = "workbook"
+	FavoriteKindDataSource FavoriteKind = "datasource"
+	FavoriteKindProject    FavoriteKind = "project"
+	FavoriteKindView       FavoriteKind = "view"
+)
+
+// FavoriteTarget identifies the content to add to or remove from favorites.
+type FavoriteTarget struct {
+	Kind FavoriteKind
+	ID   string
+	// Label is the name shown for the favorite. It is only used when adding.
+	Label string
+}
+
+// FavoriteContent is the content a favorite points to.
+type FavoriteContent struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Favorite represents a user's favorite. Exactly one of the content fields is
+// set, depending on the kind of the favorite.
+type Favorite struct {
+	Label      string           `json:"label"`
+	Workbook   *FavoriteContent `json:"workbook"`
+	DataSource *FavoriteContent `json:"datasource"`
+	Project    *FavoriteContent `json:"project"`
+	View       *FavoriteContent `json:"view"`
+}
+
+// Target returns the FavoriteTarget identifying the favorited content.
+func (f *Favorite) Target() FavoriteTarget {
+	target := FavoriteTarget{Label: f.Label}
+	switch {
+	case f.Workbook != nil:
+		target.Kind, target.ID = FavoriteKindWorkbook, f.Workbook.ID
+	case f.DataSource != nil:
+		target.Kind, target.ID = FavoriteKindDataSource, f.DataSource.ID
+	case f.Project != nil:
+		target.Kind, target.ID = FavoriteKindProject, f.Project.ID
+	case f.View != nil:
+		target.Kind, target.ID = FavoriteKindView, f.View.ID
+	}
+	return target
+}
+
+type favoritesResponse struct {
+	Favorites struct {
+		Favorite []*Favorite `json:"favorite"`
+	} `json:"favorites"`
+}
+
+type favoritesService struct {
+	client *Client
+}
+
+func (fs *favoritesService) List(ctx context.Context, userID string) ([]*Favorite, error) {
+	path := fmt.Sprintf("sites/%s/favorites/%s", fs.client.SiteID, userID)
+	req, err := fs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for list favorites")
+	}
+
+	resp := &favoritesResponse{}
+	err = fs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Favorites.Favorite, nil
+}
+
+func (fs *favoritesService) Add(ctx context.Context, userID string, target FavoriteTarget) error {
+	err := target.validate()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/favorites/%s", fs.client.SiteID, userID)
+
+	// the content element is named after the kind, i.e; {"workbook": {"id": ...}}
+	request := map[string]interface{}{
+		"favorite": map[string]interface{}{
+			"label": target.Label,
+			string(target.Kind): map[string]string{
+				"id": target.ID,
+			},
+		},
+	}
+
+	req, err := fs.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for add favorite")
+	}
+	err = fs.client.do(ctx, req, nil)
+	return err
+}
+
+func (fs *favoritesService) Delete(ctx context.Context, userID string, target FavoriteTarget) error {
+	err := target.validate()
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/favorites/%s/%ss/%s", fs.client.SiteID, userID, target.Kind, target.ID)
+	req, err := fs.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete favorite")
+	}
+	err = fs.client.do(ctx, req, nil)
+	return err
+}
+
+func (t FavoriteTarget) validate() error {
+	switch t.Kind {
+	case FavoriteKindWorkbook, FavoriteKindDataSource, FavoriteKindProject, FavoriteKindView:
+	default:
+		return errors.Errorf("unknown favorite kind %q", t.Kind)
+	}
+	if t.ID == "" {
+		return errors.New("favorite content id must not be empty")
+	}
+	return nil
+}
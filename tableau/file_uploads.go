@@ -0,0 +1,79 @@
+package tableau
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+)
+
+// fileUploadChunkSize is the size of the chunks Upload sends, below the 64 MB
+// Tableau accepts per request.
+const fileUploadChunkSize = 50 << 20
+
+type fileUploadResponse struct {
+	FileUpload struct {
+		UploadSessionID string `json:"uploadSessionId"`
+		FileSize        int    `json:"fileSize,string"`
+	} `json:"fileUpload"`
+}
+
+type fileUploadsService struct {
+	client *Client
+}
+
+// Initiate starts a file upload session and returns its ID, which is passed to
+// Append and then to the endpoint consuming the uploaded file.
+func (fus *fileUploadsService) Initiate(ctx context.Context) (string, error) {
+	path := fmt.Sprintf("sites/%s/fileUploads", fus.client.SiteID)
+	req, err := fus.client.newRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "error creating request for initiate file upload")
+	}
+
+	resp := &fileUploadResponse{}
+	err = fus.client.do(ctx, req, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.FileUpload.UploadSessionID, nil
+}
+
+// Append uploads chunk as the next part of the file of the upload session.
+func (fus *fileUploadsService) Append(ctx context.Context, uploadSessionID string, chunk io.Reader) error {
+	path := fmt.Sprintf("sites/%s/fileUploads/%s", fus.client.SiteID, uploadSessionID)
+	req, err := fus.client.newMultipartRequest(http.MethodPut, path, nil, "tableau_file", "file", chunk)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for append to file upload")
+	}
+
+	return fus.client.send(ctx, req, nil)
+}
+
+// Upload initiates an upload session and appends the content of r to it in
+// chunks. It returns the ID of the session.
+func (fus *fileUploadsService) Upload(ctx context.Context, r io.Reader) (string, error) {
+	uploadSessionID, err := fus.Initiate(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		_, err = br.Peek(1)
+		if err == io.EOF {
+			return uploadSessionID, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		err = fus.Append(ctx, uploadSessionID, io.LimitReader(br, fileUploadChunkSize))
+		if err != nil {
+			return "", err
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package tableau
+
+import (
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// FilterOperator represents an operator of a Tableau filter expression.
+type FilterOperator string
+
+const (
+	FilterOperatorEq   FilterOperator = "eq"
+	FilterOperatorCiEq FilterOperator = "cieq"
+	FilterOperatorGt   FilterOperator = "gt"
+	FilterOperatorGte  FilterOperator = "gte"
+	FilterOperatorLt   FilterOperator = "lt"
+	FilterOperatorLte  FilterOperator = "lte"
+	FilterOperatorHas  FilterOperator = "has"
+	FilterOperatorIn   FilterOperator = "in"
+)
+
+// filterValueEscaper percent-encodes the characters that delimit a filter
+// expression, so they can appear in values. Colons are left as is since
+// Tableau only splits an expression on its first two, which keeps values such
+// as timestamps intact.
+var filterValueEscaper = strings.NewReplacer(
+	"%", "%25",
+	",", "%2C",
+	"[", "%5B",
+	"]", "%5D",
+)
+
+// FilterBuilder builds a filter expression such as
+// "name:eq:Finance,createdAt:gte:2023-01-01T00:00:00Z" for use with
+// WithFilter. Invalid expressions are reported when the option is applied.
+type FilterBuilder struct {
+	exprs []string
+	err   error
+}
+
+// NewFilterBuilder returns an empty FilterBuilder.
+func NewFilterBuilder() *FilterBuilder {
+	return &FilterBuilder{}
+}
+
+// Eq adds a "field:eq:value" expression.
+func (b *FilterBuilder) Eq(field, value string) *FilterBuilder {
+	return b.Add(field, FilterOperatorEq, value)
+}
+
+// Gt adds a "field:gt:value" expression.
+func (b *FilterBuilder) Gt(field, value string) *FilterBuilder {
+	return b.Add(field, FilterOperatorGt, value)
+}
+
+// Gte adds a "field:gte:value" expression.
+func (b *FilterBuilder) Gte(field, value string) *FilterBuilder {
+	return b.Add(field, FilterOperatorGte, value)
+}
+
+// Lt adds a "field:lt:value" expression.
+func (b *FilterBuilder) Lt(field, value string) *FilterBuilder {
+	return b.Add(field, FilterOperatorLt, value)
+}
+
+// Lte adds a "field:lte:value" expression.
+func (b *FilterBuilder) Lte(field, value string) *FilterBuilder {
+	return b.Add(field, FilterOperatorLte, value)
+}
+
+// In adds a "field:in:[value1,value2]" expression.
+func (b *FilterBuilder) In(field string, values ...string) *FilterBuilder {
+	return b.Add(field, FilterOperatorIn, values...)
+}
+
+// Add adds an expression with the given operator. Only the "in" operator
+// accepts more than one value.
+func (b *FilterBuilder) Add(field string, op FilterOperator, values ...string) *FilterBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	switch {
+	case field == "":
+		b.err = errors.New("filter field must not be empty")
+		return b
+	case !op.valid():
+		b.err = errors.Errorf("unknown filter operator %q", op)
+		return b
+	case len(values) == 0:
+		b.err = errors.Errorf("filter on %q has no value", field)
+		return b
+	case op != FilterOperatorIn && len(values) > 1:
+		b.err = errors.Errorf("filter operator %q accepts a single value", op)
+		return b
+	}
+
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = filterValueEscaper.Replace(v)
+	}
+
+	value := escaped[0]
+	if op == FilterOperatorIn {
+		value = "[" + strings.Join(escaped, ",") + "]"
+	}
+
+	b.exprs = append(b.exprs, field+":"+string(op)+":"+value)
+	return b
+}
+
+// Err returns the first error encountered while building the expression.
+func (b *FilterBuilder) Err() error { return b.err }
+
+// Build returns the filter expression.
+func (b *FilterBuilder) Build() string {
+	return strings.Join(b.exprs, ",")
+}
+
+func (op FilterOperator) valid() bool {
+	switch op {
+	case FilterOperatorEq, FilterOperatorCiEq, FilterOperatorGt, FilterOperatorGte,
+		FilterOperatorLt, FilterOperatorLte, FilterOperatorHas, FilterOperatorIn:
+		return true
+	}
+	return false
+}
+
+// WithFilter returns a QueryOption that sets the "filter" URL parameter from
+// the expression built by b.
+func WithFilter(b *FilterBuilder) QueryOption {
+	return func(opt *QueryOptions) error {
+		if err := b.Err(); err != nil {
+			return err
+		}
+		return WithFilterExpression(b.Build())(opt)
+	}
+}
@@ -0,0 +1,55 @@
+package tableau
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestFilterBuilder(t *testing.T) {
+	tests := []struct {
+		desc    string
+		builder *FilterBuilder
+		want    string
+		wantURL string
+		wantErr string
+	}{
+		{
+			desc:    "comma joined expressions",
+			builder: NewFilterBuilder().Eq("name", "Finance").Gte("createdAt", "2023-01-01T00:00:00Z"),
+			want:    "name:eq:Finance,createdAt:gte:2023-01-01T00:00:00Z",
+			wantURL: "projects?filter=name%3Aeq%3AFinance%2CcreatedAt%3Agte%3A2023-01-01T00%3A00%3A00Z",
+		},
+		{
+			desc:    "in operator",
+			builder: NewFilterBuilder().In("tags", "a", "b,c"),
+			want:    "tags:in:[a,b%2Cc]",
+			wantURL: "projects?filter=tags%3Ain%3A%5Ba%2Cb%252Cc%5D",
+		},
+		{
+			desc:    "unknown operator",
+			builder: NewFilterBuilder().Add("name", "like", "x"),
+			wantErr: `unknown filter operator "like"`,
+		},
+		{
+			desc:    "multiple values for single value operator",
+			builder: NewFilterBuilder().Add("name", FilterOperatorEq, "a", "b"),
+			wantErr: `filter operator "eq" accepts a single value`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+
+			path, err := applyQueryOptions("projects", []QueryOption{WithFilter(tt.builder)})
+			if tt.wantErr != "" {
+				c.Assert(err, qt.ErrorMatches, tt.wantErr)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(tt.builder.Build(), qt.Equals, tt.want)
+			c.Assert(path, qt.Equals, tt.wantURL)
+		})
+	}
+}
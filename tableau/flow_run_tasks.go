@@ -0,0 +1,102 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+// FlowRunTask represents a scheduled run of a flow.
+type FlowRunTask struct {
+	ID                     string    `json:"id"`
+	Priority               int       `json:"priority,string"`
+	ConsecutiveFailedCount int       `json:"consecutiveFailedCount,string"`
+	Type                   string    `json:"type"`
+	Schedule               *Schedule `json:"schedule"`
+	Flow                   struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"flow"`
+}
+
+type flowRunTaskPayload struct {
+	FlowRun *FlowRunTask `json:"flowRun"`
+}
+
+type flowRunTaskResponse struct {
+	Task *flowRunTaskPayload `json:"task"`
+}
+
+type queryFlowRunTasksResponse struct {
+	Tasks struct {
+		Task []*flowRunTaskPayload `json:"task"`
+	} `json:"tasks"`
+}
+
+type flowRunTasksService struct {
+	client *Client
+}
+
+func (frts *flowRunTasksService) Query(ctx context.Context) ([]*FlowRunTask, error) {
+	path := fmt.Sprintf("sites/%s/tasks/runFlow", frts.client.SiteID)
+	req, err := frts.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query flow run tasks")
+	}
+
+	resp := &queryFlowRunTasksResponse{}
+	err = frts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*FlowRunTask, 0, len(resp.Tasks.Task))
+	for _, t := range resp.Tasks.Task {
+		tasks = append(tasks, t.FlowRun)
+	}
+	return tasks, nil
+}
+
+func (frts *flowRunTasksService) Get(ctx context.Context, id string) (*FlowRunTask, error) {
+	path := fmt.Sprintf("sites/%s/tasks/runFlow/%s", frts.client.SiteID, id)
+	req, err := frts.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get flow run task")
+	}
+
+	resp := &flowRunTaskResponse{}
+	err = frts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Task.FlowRun, nil
+}
+
+func (frts *flowRunTasksService) Delete(ctx context.Context, id string) error {
+	path := fmt.Sprintf("sites/%s/tasks/runFlow/%s", frts.client.SiteID, id)
+	req, err := frts.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete flow run task")
+	}
+	err = frts.client.do(ctx, req, nil)
+	return err
+}
+
+// RunNow runs the flow run task outside of its schedule and returns the job
+// running it.
+func (frts *flowRunTasksService) RunNow(ctx context.Context, id string) (*Job, error) {
+	path := fmt.Sprintf("sites/%s/tasks/runFlow/%s/runNow", frts.client.SiteID, id)
+	req, err := frts.client.newRequest(http.MethodPost, path, struct{}{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for run flow run task")
+	}
+
+	resp := &jobResponse{}
+	err = frts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Job, nil
+}
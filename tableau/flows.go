@@ -0,0 +1,365 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Flow represents a Tableau Prep flow
+type Flow struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	WebpageURL  string `json:"webpageUrl"`
+	FileType    string `json:"fileType"`
+	Project     struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+	Owner struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"owner"`
+	Tags      Tags      `json:"tags"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PublishFlowRequest encapsulates the request for publishing a flow.
+type PublishFlowRequest struct {
+	Name string
+	// FileName is the name of the uploaded file. Its extension, ".tfl" or
+	// ".tflx", decides the type of the flow.
+	FileName  string
+	ProjectID string
+	// Overwrite replaces an existing flow with the same name.
+	Overwrite bool
+}
+
+type publishFlowPayload struct {
+	Name    string     `json:"name"`
+	Project resourceID `json:"project"`
+}
+
+type flowResponse struct {
+	Flow *Flow `json:"flow"`
+}
+
+type queryFlowsResponse struct {
+	Pagination Pagination
+	Flows      struct {
+		Flow []*Flow `json:"flow"`
+	} `json:"flows"`
+}
+
+// FlowRun represents a single run of a flow.
+type FlowRun struct {
+	ID              string    `json:"id"`
+	FlowID          string    `json:"flowId"`
+	Status          string    `json:"status"`
+	Progress        int       `json:"progress,string"`
+	BackgroundJobID string    `json:"backgroundJobId"`
+	StartedAt       time.Time `json:"startedAt"`
+	CompletedAt     time.Time `json:"completedAt"`
+}
+
+// RunFlowRequest encapsulates the options of running a flow. A nil request
+// runs all the outputs of the flow with its default parameters.
+type RunFlowRequest struct {
+	// OutputStepIDs restricts the run to the given output steps.
+	OutputStepIDs []string
+	// Parameters overrides flow parameter values, by parameter id.
+	Parameters map[string]string
+	// RunMode is either "full" or "incremental".
+	RunMode string
+}
+
+type flowRunSpec struct {
+	RunMode     string `json:"runMode,omitempty"`
+	OutputSteps *struct {
+		Step []*resourceID `json:"flowOutputStep"`
+	} `json:"flowOutputSteps,omitempty"`
+	ParameterSpecs *struct {
+		Spec []*flowParameterSpec `json:"flowParameterSpec"`
+	} `json:"flowParameterSpecs,omitempty"`
+}
+
+type flowParameterSpec struct {
+	ParameterID   string `json:"parameterId"`
+	OverrideValue string `json:"overrideValue"`
+}
+
+type flowRunResponse struct {
+	FlowRun *FlowRun `json:"flowRun"`
+}
+
+type queryFlowRunsResponse struct {
+	Pagination Pagination
+	FlowRuns   struct {
+		FlowRun []*FlowRun `json:"flowRuns"`
+	} `json:"flowRuns"`
+}
+
+type flowsService struct {
+	client *Client
+}
+
+func (fs *flowsService) Query(ctx context.Context, opts ...QueryOption) ([]*Flow, *Pagination, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/flows", fs.client.SiteID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := fs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query flows")
+	}
+
+	resp := &queryFlowsResponse{}
+	err = fs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Flows.Flow, &resp.Pagination, nil
+}
+
+func (fs *flowsService) Get(ctx context.Context, id string) (*Flow, error) {
+	path := fmt.Sprintf("sites/%s/flows/%s", fs.client.SiteID, id)
+	req, err := fs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get flow")
+	}
+
+	resp := &flowResponse{}
+	err = fs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Flow, nil
+}
+
+func (fs *flowsService) Publish(ctx context.Context, publishReq *PublishFlowRequest, r io.Reader) (*Flow, error) {
+	flowType := strings.TrimPrefix(strings.ToLower(filepath.Ext(publishReq.FileName)), ".")
+	if flowType != "tfl" && flowType != "tflx" {
+		return nil, errors.Errorf("unsupported flow file %q, expected a .tfl or .tflx file", publishReq.FileName)
+	}
+
+	query := url.Values{}
+	query.Set("flowType", flowType)
+	if publishReq.Overwrite {
+		query.Set("overwrite", "true")
+	}
+	path := fmt.Sprintf("sites/%s/flows?%s", fs.client.SiteID, query.Encode())
+
+	request := struct {
+		Flow publishFlowPayload `json:"flow"`
+	}{
+		Flow: publishFlowPayload{
+			Name:    publishReq.Name,
+			Project: resourceID{ID: publishReq.ProjectID},
+		},
+	}
+
+	req, err := fs.client.newMultipartRequest(http.MethodPost, path, request, "tableau_flow", publishReq.FileName, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for publish flow")
+	}
+
+	resp := &flowResponse{}
+	err = fs.client.send(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Flow, nil
+}
+
+func (fs *flowsService) Download(ctx context.Context, id string, w io.Writer) error {
+	path := fmt.Sprintf("sites/%s/flows/%s/content", fs.client.SiteID, id)
+	req, err := fs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for download flow")
+	}
+
+	_, err = fs.client.download(ctx, req, w)
+	return err
+}
+
+func (fs *flowsService) Delete(ctx context.Context, id string) error {
+	path := fmt.Sprintf("sites/%s/flows/%s", fs.client.SiteID, id)
+	req, err := fs.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete flow")
+	}
+	err = fs.client.do(ctx, req, nil)
+	return err
+}
+
+// Runs returns the runs of the flows of the site, i.e; filtered by flowId with
+// WithFilterExpression.
+func (fs *flowsService) Runs(ctx context.Context, opts ...QueryOption) ([]*FlowRun, *Pagination, error) {
+	err := fs.client.requireAPIVersion("3.10", "flow runs")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/flows/runs", fs.client.SiteID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := fs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query flow runs")
+	}
+
+	resp := &queryFlowRunsResponse{}
+	err = fs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.FlowRuns.FlowRun, &resp.Pagination, nil
+}
+
+func (fs *flowsService) GetRun(ctx context.Context, runID string) (*FlowRun, error) {
+	err := fs.client.requireAPIVersion("3.10", "flow runs")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/flows/runs/%s", fs.client.SiteID, runID)
+	req, err := fs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get flow run")
+	}
+
+	resp := &flowRunResponse{}
+	err = fs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.FlowRun, nil
+}
+
+// CancelRun cancels a flow run that is pending or in progress.
+func (fs *flowsService) CancelRun(ctx context.Context, runID string) error {
+	err := fs.client.requireAPIVersion("3.13", "cancel flow run")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/flows/runs/%s", fs.client.SiteID, runID)
+	req, err := fs.client.newRequest(http.MethodPut, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for cancel flow run")
+	}
+	err = fs.client.do(ctx, req, nil)
+	return err
+}
+
+// RunNow runs the flow and returns the job running it.
+func (fs *flowsService) RunNow(ctx context.Context, flowID string, runReq *RunFlowRequest) (*Job, error) {
+	path := fmt.Sprintf("sites/%s/flows/%s/run", fs.client.SiteID, flowID)
+
+	spec := &flowRunSpec{}
+	if runReq != nil {
+		if len(runReq.Parameters) > 0 {
+			err := fs.client.requireAPIVersion("3.15", "flow parameters")
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		spec.RunMode = runReq.RunMode
+		if len(runReq.OutputStepIDs) > 0 {
+			spec.OutputSteps = &struct {
+				Step []*resourceID `json:"flowOutputStep"`
+			}{}
+			for _, id := range runReq.OutputStepIDs {
+				spec.OutputSteps.Step = append(spec.OutputSteps.Step, &resourceID{ID: id})
+			}
+		}
+		if len(runReq.Parameters) > 0 {
+			ids := make([]string, 0, len(runReq.Parameters))
+			for id := range runReq.Parameters {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+
+			spec.ParameterSpecs = &struct {
+				Spec []*flowParameterSpec `json:"flowParameterSpec"`
+			}{}
+			for _, id := range ids {
+				spec.ParameterSpecs.Spec = append(spec.ParameterSpecs.Spec, &flowParameterSpec{ParameterID: id, OverrideValue: runReq.Parameters[id]})
+			}
+		}
+	}
+
+	request := struct {
+		FlowRunSpec *flowRunSpec `json:"flowRunSpec"`
+	}{
+		FlowRunSpec: spec,
+	}
+
+	req, err := fs.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for run flow")
+	}
+
+	resp := &jobResponse{}
+	err = fs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Job, nil
+}
+
+func (fs *flowsService) Connections(ctx context.Context, id string) ([]*Connection, error) {
+	path := fmt.Sprintf("sites/%s/flows/%s/connections", fs.client.SiteID, id)
+	req, err := fs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for list flow connections")
+	}
+
+	resp := &connectionsResponse{}
+	err = fs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Connections.Connection, nil
+}
+
+func (fs *flowsService) UpdateConnection(ctx context.Context, flowID, connectionID string, updateReq *UpdateConnectionRequest) (*Connection, error) {
+	path := fmt.Sprintf("sites/%s/flows/%s/connections/%s", fs.client.SiteID, flowID, connectionID)
+
+	request := struct {
+		Connection *UpdateConnectionRequest `json:"connection"`
+	}{
+		Connection: updateReq,
+	}
+	req, err := fs.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update flow connection")
+	}
+
+	resp := &connectionResponse{}
+	err = fs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Connection, nil
+}
@@ -0,0 +1,41 @@
+package tableau
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestFlowsRunNow(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.15/sites/site-id/flows/flow-id/run", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+			"flowRunSpec": map[string]interface{}{
+				"runMode": "full",
+				"flowOutputSteps": map[string]interface{}{
+					"flowOutputStep": []interface{}{map[string]interface{}{"id": "step-id"}},
+				},
+				"flowParameterSpecs": map[string]interface{}{
+					"flowParameterSpec": []interface{}{
+						map[string]interface{}{"parameterId": "a", "overrideValue": "1"},
+						map[string]interface{}{"parameterId": "b", "overrideValue": "2"},
+					},
+				},
+			},
+		})
+		_, _ = w.Write([]byte(`{"job": {"id": "job-id"}}`))
+	}, WithAPIVersion("3.15"))
+
+	job, err := client.Flows.RunNow(context.Background(), "flow-id", &RunFlowRequest{
+		OutputStepIDs: []string{"step-id"},
+		Parameters:    map[string]string{"b": "2", "a": "1"},
+		RunMode:       "full",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(job.ID, qt.Equals, "job-id")
+}
@@ -0,0 +1,179 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+const groupSetsMinAPIVersion = "3.22"
+
+// GroupSet represents a Tableau group set, a named collection of groups that
+// permissions can be granted to.
+type GroupSet struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	GroupCount int    `json:"groupCount,string"`
+	// Groups is only populated by Get.
+	Groups struct {
+		Group []*Group `json:"group"`
+	} `json:"groups"`
+}
+
+type groupSetResponse struct {
+	GroupSet *GroupSet `json:"groupSet"`
+}
+
+type queryGroupSetsResponse struct {
+	Pagination Pagination
+	GroupSets  struct {
+		GroupSet []*GroupSet `json:"groupSet"`
+	} `json:"groupSets"`
+}
+
+type groupSetsService struct {
+	client *Client
+}
+
+func (gss *groupSetsService) Create(ctx context.Context, name string) (*GroupSet, error) {
+	err := gss.client.requireAPIVersion(groupSetsMinAPIVersion, "group sets")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/groupsets", gss.client.SiteID)
+
+	request := struct {
+		GroupSet struct {
+			Name string `json:"name"`
+		} `json:"groupSet"`
+	}{}
+	request.GroupSet.Name = name
+
+	req, err := gss.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for create group set")
+	}
+
+	resp := &groupSetResponse{}
+	err = gss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GroupSet, nil
+}
+
+func (gss *groupSetsService) Query(ctx context.Context, opts ...QueryOption) ([]*GroupSet, *Pagination, error) {
+	err := gss.client.requireAPIVersion(groupSetsMinAPIVersion, "group sets")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/groupsets", gss.client.SiteID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := gss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query group sets")
+	}
+
+	resp := &queryGroupSetsResponse{}
+	err = gss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.GroupSets.GroupSet, &resp.Pagination, nil
+}
+
+// Get returns the group set with the given id along with its groups.
+func (gss *groupSetsService) Get(ctx context.Context, id string) (*GroupSet, error) {
+	err := gss.client.requireAPIVersion(groupSetsMinAPIVersion, "group sets")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/groupsets/%s", gss.client.SiteID, id)
+	req, err := gss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get group set")
+	}
+
+	resp := &groupSetResponse{}
+	err = gss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GroupSet, nil
+}
+
+// Update renames the group set. Tableau takes the new name as a query
+// parameter rather than in the request body.
+func (gss *groupSetsService) Update(ctx context.Context, id, name string) (*GroupSet, error) {
+	err := gss.client.requireAPIVersion(groupSetsMinAPIVersion, "group sets")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/groupsets/%s?name=%s", gss.client.SiteID, id, url.QueryEscape(name))
+	req, err := gss.client.newRequest(http.MethodPut, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update group set")
+	}
+
+	resp := &groupSetResponse{}
+	err = gss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GroupSet, nil
+}
+
+func (gss *groupSetsService) Delete(ctx context.Context, id string) error {
+	err := gss.client.requireAPIVersion(groupSetsMinAPIVersion, "group sets")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/groupsets/%s", gss.client.SiteID, id)
+	req, err := gss.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete group set")
+	}
+	err = gss.client.do(ctx, req, nil)
+	return err
+}
+
+func (gss *groupSetsService) AddGroup(ctx context.Context, groupSetID, groupID string) error {
+	err := gss.client.requireAPIVersion(groupSetsMinAPIVersion, "group sets")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/groupsets/%s/groups/%s", gss.client.SiteID, groupSetID, groupID)
+	req, err := gss.client.newRequest(http.MethodPut, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for add group to group set")
+	}
+	err = gss.client.do(ctx, req, nil)
+	return err
+}
+
+func (gss *groupSetsService) RemoveGroup(ctx context.Context, groupSetID, groupID string) error {
+	err := gss.client.requireAPIVersion(groupSetsMinAPIVersion, "group sets")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/groupsets/%s/groups/%s", gss.client.SiteID, groupSetID, groupID)
+	req, err := gss.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for remove group from group set")
+	}
+	err = gss.client.do(ctx, req, nil)
+	return err
+}
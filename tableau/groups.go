@@ -0,0 +1,264 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+// GrantLicenseMode represents when members of a group get the minimum site
+// role of the group.
+type GrantLicenseMode string
+
+const (
+	GrantLicenseModeOnLogin GrantLicenseMode = "onLogin"
+	GrantLicenseModeOnSync  GrantLicenseMode = "onSync"
+)
+
+// Group represents a Tableau group
+type Group struct {
+	ID               string           `json:"id"`
+	Name             string           `json:"name"`
+	MinimumSiteRole  SiteRole         `json:"minimumSiteRole"`
+	GrantLicenseMode GrantLicenseMode `json:"grantLicenseMode"`
+	Domain           struct {
+		Name string `json:"name"`
+	} `json:"domain"`
+	// Import is set for groups imported from Active Directory.
+	Import *GroupImport `json:"import"`
+}
+
+// GroupImport describes the Active Directory group a Tableau group is
+// imported from.
+type GroupImport struct {
+	DomainName       string           `json:"domainName"`
+	SiteRole         SiteRole         `json:"siteRole,omitempty"`
+	GrantLicenseMode GrantLicenseMode `json:"grantLicenseMode,omitempty"`
+}
+
+// groupImportPayload is the wire representation of GroupImport.
+type groupImportPayload struct {
+	Source string `json:"source"`
+	*GroupImport
+}
+
+// CreateGroupRequest encapsulates the request for creating a new local group.
+type CreateGroupRequest struct {
+	Name             string           `json:"name"`
+	MinimumSiteRole  SiteRole         `json:"minimumSiteRole,omitempty"`
+	GrantLicenseMode GrantLicenseMode `json:"grantLicenseMode,omitempty"`
+}
+
+// UpdateGroupRequest encapsulates the request for updating a local group.
+// Empty fields are left unchanged on the server.
+type UpdateGroupRequest struct {
+	ID               string           `json:"-"`
+	Name             string           `json:"name,omitempty"`
+	MinimumSiteRole  SiteRole         `json:"minimumSiteRole,omitempty"`
+	GrantLicenseMode GrantLicenseMode `json:"grantLicenseMode,omitempty"`
+}
+
+type groupResponse struct {
+	Group *Group `json:"group"`
+}
+
+type queryGroupsResponse struct {
+	Pagination Pagination
+	Groups     struct {
+		Group []*Group `json:"group"`
+	} `json:"groups"`
+}
+
+type groupsService struct {
+	client *Client
+}
+
+func (gs *groupsService) Query(ctx context.Context, opts ...QueryOption) ([]*Group, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/groups", gs.client.SiteID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := gs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query groups")
+	}
+
+	resp := &queryGroupsResponse{}
+	err = gs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Groups.Group, nil
+}
+
+func (gs *groupsService) Create(ctx context.Context, createReq *CreateGroupRequest) (*Group, error) {
+	path := fmt.Sprintf("sites/%s/groups", gs.client.SiteID)
+
+	request := struct {
+		Group *CreateGroupRequest `json:"group"`
+	}{
+		Group: createReq,
+	}
+
+	req, err := gs.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for create group")
+	}
+
+	resp := &groupResponse{}
+	err = gs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Group, nil
+}
+
+func (gs *groupsService) Update(ctx context.Context, updateReq *UpdateGroupRequest) (*Group, error) {
+	path := fmt.Sprintf("sites/%s/groups/%s", gs.client.SiteID, updateReq.ID)
+
+	request := struct {
+		Group *UpdateGroupRequest `json:"group"`
+	}{
+		Group: updateReq,
+	}
+
+	req, err := gs.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update group")
+	}
+
+	resp := &groupResponse{}
+	err = gs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Group, nil
+}
+
+func (gs *groupsService) Delete(ctx context.Context, id string) error {
+	path := fmt.Sprintf("sites/%s/groups/%s", gs.client.SiteID, id)
+	req, err := gs.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete group")
+	}
+	err = gs.client.do(ctx, req, nil)
+	return err
+}
+
+// ListUsers returns the first page of the members of the group.
+//
+// Deprecated: use Users, which supports paging, instead.
+func (gs *groupsService) ListUsers(ctx context.Context, groupID string) ([]*User, error) {
+	users, _, err := gs.Users(ctx, groupID)
+	return users, err
+}
+
+// Users returns a page of the members of the group along with the pagination
+// details needed to fetch the next pages.
+func (gs *groupsService) Users(ctx context.Context, groupID string, opts ...QueryOption) ([]*User, *Pagination, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/groups/%s/users", gs.client.SiteID, groupID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := gs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query group users")
+	}
+
+	resp := &usersResponse{}
+	err = gs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Users.User, &resp.Pagination, nil
+}
+
+func (gs *groupsService) AddUser(ctx context.Context, groupID, userID string) error {
+	path := fmt.Sprintf("sites/%s/groups/%s/users", gs.client.SiteID, groupID)
+
+	request := struct {
+		User struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}{}
+	request.User.ID = userID
+
+	req, err := gs.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for add user to group")
+	}
+	err = gs.client.do(ctx, req, nil)
+	return err
+}
+
+func (gs *groupsService) RemoveUser(ctx context.Context, groupID, userID string) error {
+	path := fmt.Sprintf("sites/%s/groups/%s/users/%s", gs.client.SiteID, groupID, userID)
+	req, err := gs.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for remove user from group")
+	}
+	err = gs.client.do(ctx, req, nil)
+	return err
+}
+
+// AddUsers adds the given users to the group, adding several users
+// concurrently and retrying throttled or failed requests. If some users
+// couldn't be added, a *BulkError listing them is returned.
+func (gs *groupsService) AddUsers(ctx context.Context, groupID string, userIDs []string) error {
+	return runBulk(ctx, userIDs, func(ctx context.Context, userID string) error {
+		return gs.AddUser(ctx, groupID, userID)
+	})
+}
+
+// RemoveUsers removes the given users from the group like AddUsers adds them.
+func (gs *groupsService) RemoveUsers(ctx context.Context, groupID string, userIDs []string) error {
+	return runBulk(ctx, userIDs, func(ctx context.Context, userID string) error {
+		return gs.RemoveUser(ctx, groupID, userID)
+	})
+}
+
+// ImportFromAD creates a group from the Active Directory group with the given
+// name. The members are imported asynchronously, so the job importing them is
+// returned.
+func (gs *groupsService) ImportFromAD(ctx context.Context, name string, groupImport *GroupImport) (*Job, error) {
+	path := fmt.Sprintf("sites/%s/groups?asJob=true", gs.client.SiteID)
+	return gs.importFromAD(ctx, http.MethodPost, path, name, groupImport)
+}
+
+// SyncWithAD updates the members of a group imported from Active Directory.
+// The sync runs asynchronously, so the job running it is returned.
+func (gs *groupsService) SyncWithAD(ctx context.Context, groupID, name string, groupImport *GroupImport) (*Job, error) {
+	path := fmt.Sprintf("sites/%s/groups/%s?asJob=true", gs.client.SiteID, groupID)
+	return gs.importFromAD(ctx, http.MethodPut, path, name, groupImport)
+}
+
+func (gs *groupsService) importFromAD(ctx context.Context, method, path, name string, groupImport *GroupImport) (*Job, error) {
+	request := struct {
+		Group struct {
+			Name   string             `json:"name"`
+			Import groupImportPayload `json:"import"`
+		} `json:"group"`
+	}{}
+	request.Group.Name = name
+	request.Group.Import = groupImportPayload{
+		Source:      "ActiveDirectory",
+		GroupImport: groupImport,
+	}
+
+	req, err := gs.client.newRequest(method, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for import group from active directory")
+	}
+
+	resp := &jobResponse{}
+	err = gs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Job, nil
+}
@@ -0,0 +1,54 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGroupsUsersPaging(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/groups/group-id/users", testGroupMembersHandler)
+
+	users, pagination, err := client.Groups.Users(context.Background(), "group-id", WithPageSize(2), WithPageNumber(2))
+	c.Assert(err, qt.IsNil)
+	c.Assert(users, qt.HasLen, 1)
+	c.Assert(*pagination, qt.Equals, Pagination{PageNumber: 2, PageSize: 2, TotalAvailable: 3})
+
+	first, err := client.Groups.ListUsers(context.Background(), "group-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(first, qt.HasLen, 2)
+}
+
+// testGroupMembersHandler serves the three members of the group "group-id".
+// Like the server, it caps pages at two users whatever the requested size.
+func testGroupMembersHandler(w http.ResponseWriter, r *http.Request) {
+	page := r.URL.Query().Get("pageNumber")
+	if page == "" {
+		page = "1"
+	}
+	users := `{"id": "` + page + `-a"}, {"id": "` + page + `-b"}`
+	if page == "2" {
+		users = `{"id": "2-a"}`
+	}
+	fmt.Fprintf(w, `{
+		"pagination": {"pageNumber": "%s", "pageSize": "2", "totalAvailable": "3"},
+		"users": {"user": [%s]}
+	}`, page, users)
+}
+
+func TestClientGroupMembers(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/groups/group-id/users", testGroupMembersHandler)
+
+	members, err := client.groupMembers(context.Background(), "group-id")
+	c.Assert(err, qt.IsNil)
+	var ids []string
+	for _, u := range members {
+		ids = append(ids, u.ID)
+	}
+	c.Assert(ids, qt.DeepEquals, []string{"1-a", "1-b", "2-a"})
+}
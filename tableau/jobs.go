@@ -0,0 +1,278 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Job represents an asynchronous Tableau job, i.e; an extract refresh.
+type Job struct {
+	ID          string    `json:"id"`
+	Mode        string    `json:"mode"`
+	Type        string    `json:"type"`
+	Progress    int       `json:"progress,string"`
+	FinishCode  int       `json:"finishCode,string"`
+	CreatedAt   time.Time `json:"createdAt"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+	// ExtractRefreshJob is set for extract refresh jobs returned by Get.
+	ExtractRefreshJob *ExtractRefreshJob `json:"extractRefreshJob"`
+	StatusNotes       struct {
+		StatusNote []*JobStatusNote `json:"statusNote"`
+	} `json:"statusNotes"`
+}
+
+// Job finish codes, only meaningful once the job has completed.
+const (
+	JobFinishCodeSuccess   = 0
+	JobFinishCodeFailed    = 1
+	JobFinishCodeCancelled = 2
+)
+
+// ExtractRefreshJob holds the details of an extract refresh job.
+type ExtractRefreshJob struct {
+	// Notes holds the failure details of a failed refresh.
+	Notes      string `json:"notes"`
+	DataSource *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"datasource"`
+	Workbook *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"workbook"`
+}
+
+// JobStatusNote is a note the server attached to a job, i.e; the reason it
+// failed.
+type JobStatusNote struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Text  string `json:"text"`
+}
+
+type jobResponse struct {
+	Job *Job `json:"job"`
+}
+
+// JobType represents the type of a background job.
+type JobType string
+
+const (
+	JobTypeRefreshExtracts   JobType = "refresh_extracts"
+	JobTypeIncrementExtracts JobType = "increment_extracts"
+	JobTypeRunFlow           JobType = "run_flow"
+	JobTypePublishDataSource JobType = "publish_datasource"
+	JobTypeEncryptExtracts   JobType = "encrypt_extracts"
+	JobTypeDecryptExtracts   JobType = "decrypt_extracts"
+)
+
+// JobStatus represents the status of a background job.
+type JobStatus string
+
+const (
+	JobStatusPending    JobStatus = "Pending"
+	JobStatusInProgress JobStatus = "InProgress"
+	JobStatusSuccess    JobStatus = "Success"
+	JobStatusFailed     JobStatus = "Failed"
+	JobStatusCancelled  JobStatus = "Cancelled"
+)
+
+// BackgroundJob represents a job as listed by Jobs.Query.
+type BackgroundJob struct {
+	ID        string    `json:"id"`
+	Status    JobStatus `json:"status"`
+	JobType   JobType   `json:"jobType"`
+	Priority  int       `json:"priority,string"`
+	Title     string    `json:"title"`
+	Subtitle  string    `json:"subtitle"`
+	CreatedAt time.Time `json:"createdAt"`
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+}
+
+type queryJobsResponse struct {
+	Pagination     Pagination
+	BackgroundJobs struct {
+		BackgroundJob []*BackgroundJob `json:"backgroundJob"`
+	} `json:"backgroundJobs"`
+}
+
+// JobFilter selects the jobs returned by Jobs.Query. Zero fields don't
+// filter.
+type JobFilter struct {
+	JobTypes      []JobType
+	Statuses      []JobStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	MinProgress   *int
+	MaxProgress   *int
+}
+
+// WithJobFilter returns a QueryOption that sets the "filter" URL parameter
+// from f.
+func WithJobFilter(f *JobFilter) QueryOption {
+	b := NewFilterBuilder()
+	if len(f.JobTypes) > 0 {
+		types := make([]string, len(f.JobTypes))
+		for i, t := range f.JobTypes {
+			types[i] = string(t)
+		}
+		b.In("jobType", types...)
+	}
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, len(f.Statuses))
+		for i, s := range f.Statuses {
+			statuses[i] = string(s)
+		}
+		b.In("status", statuses...)
+	}
+	if !f.CreatedAfter.IsZero() {
+		b.Gte("createdAt", f.CreatedAfter.UTC().Format(time.RFC3339))
+	}
+	if !f.CreatedBefore.IsZero() {
+		b.Lte("createdAt", f.CreatedBefore.UTC().Format(time.RFC3339))
+	}
+	if f.MinProgress != nil {
+		b.Gte("progress", strconv.Itoa(*f.MinProgress))
+	}
+	if f.MaxProgress != nil {
+		b.Lte("progress", strconv.Itoa(*f.MaxProgress))
+	}
+	return WithFilter(b)
+}
+
+// ErrJobFailed is returned by Jobs.Wait when the job completed with a failure.
+var ErrJobFailed = errors.New("job failed")
+
+// ErrJobCancelled is returned by Jobs.Wait when the job was cancelled.
+var ErrJobCancelled = errors.New("job cancelled")
+
+// WaitOptions are options for Jobs.Wait.
+type WaitOptions struct {
+	// PollInterval is the delay before the first poll. It doubles after every
+	// poll, up to MaxPollInterval. A non-positive PollInterval defaults to
+	// one second and a MaxPollInterval below it is raised to PollInterval.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+}
+
+type WaitOption func(*WaitOptions)
+
+// WithPollInterval returns a WaitOption that sets the initial and the maximum
+// delay between polls.
+func WithPollInterval(initial, max time.Duration) WaitOption {
+	return func(opt *WaitOptions) {
+		opt.PollInterval = initial
+		opt.MaxPollInterval = max
+	}
+}
+
+type jobsService struct {
+	client *Client
+}
+
+func (js *jobsService) Query(ctx context.Context, opts ...QueryOption) ([]*BackgroundJob, *Pagination, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/jobs", js.client.SiteID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := js.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query jobs")
+	}
+
+	resp := &queryJobsResponse{}
+	err = js.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.BackgroundJobs.BackgroundJob, &resp.Pagination, nil
+}
+
+func (js *jobsService) Get(ctx context.Context, id string) (*Job, error) {
+	path := fmt.Sprintf("sites/%s/jobs/%s", js.client.SiteID, id)
+	req, err := js.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get job")
+	}
+
+	resp := &jobResponse{}
+	err = js.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// Cancel cancels the job with the given id. Jobs that already completed can't
+// be cancelled.
+func (js *jobsService) Cancel(ctx context.Context, id string) error {
+	path := fmt.Sprintf("sites/%s/jobs/%s", js.client.SiteID, id)
+	req, err := js.client.newRequest(http.MethodPut, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for cancel job")
+	}
+	err = js.client.do(ctx, req, nil)
+	return err
+}
+
+// Wait polls the job with the given id until it completes or ctx is done,
+// backing off exponentially between polls, and returns the completed job. If
+// the job failed or was cancelled, the job is returned along with
+// ErrJobFailed or ErrJobCancelled.
+func (js *jobsService) Wait(ctx context.Context, id string, opts ...WaitOption) (*Job, error) {
+	waitOpts := &WaitOptions{
+		PollInterval:    time.Second,
+		MaxPollInterval: 30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(waitOpts)
+	}
+
+	if waitOpts.PollInterval <= 0 {
+		waitOpts.PollInterval = time.Second
+	}
+	if waitOpts.MaxPollInterval < waitOpts.PollInterval {
+		waitOpts.MaxPollInterval = waitOpts.PollInterval
+	}
+
+	delay := waitOpts.PollInterval
+	for {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		job, err := js.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !job.CompletedAt.IsZero() {
+			switch job.FinishCode {
+			case JobFinishCodeSuccess:
+				return job, nil
+			case JobFinishCodeCancelled:
+				return job, errors.Wrap(ErrJobCancelled, id)
+			default:
+				return job, errors.Wrap(ErrJobFailed, id)
+			}
+		}
+
+		delay *= 2
+		if delay > waitOpts.MaxPollInterval {
+			delay = waitOpts.MaxPollInterval
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package tableau
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestJobsQueryFilter(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/jobs", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Query().Get("filter"), qt.Equals,
+			"jobType:in:[refresh_extracts,run_flow],status:in:[Failed],createdAt:gte:2023-01-01T00:00:00Z,progress:lte:50")
+		_, _ = w.Write([]byte(`{
+			"pagination": {"pageNumber": "1", "pageSize": "100", "totalAvailable": "1"},
+			"backgroundJobs": {"backgroundJob": [{"id": "job-id", "status": "Failed", "jobType": "refresh_extracts"}]}
+		}`))
+	})
+
+	maxProgress := 50
+	jobs, _, err := client.Jobs.Query(context.Background(), WithJobFilter(&JobFilter{
+		JobTypes:     []JobType{JobTypeRefreshExtracts, JobTypeRunFlow},
+		Statuses:     []JobStatus{JobStatusFailed},
+		CreatedAfter: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		MaxProgress:  &maxProgress,
+	}))
+	c.Assert(err, qt.IsNil)
+	c.Assert(jobs, qt.HasLen, 1)
+	c.Assert(jobs[0].Status, qt.Equals, JobStatusFailed)
+}
+
+func TestJobsWait(t *testing.T) {
+	c := qt.New(t)
+	polls := 0
+	client := newTestClient(t, "/api/3.4/sites/site-id/jobs/job-id", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			_, _ = w.Write([]byte(`{"job": {"id": "job-id", "progress": "50"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"job": {"id": "job-id", "progress": "100", "finishCode": "1", "completedAt": "2023-01-01T00:00:00Z",
+			"extractRefreshJob": {"notes": "connection refused"}}}`))
+	})
+
+	job, err := client.Jobs.Wait(context.Background(), "job-id", WithPollInterval(time.Millisecond, 2*time.Millisecond))
+	c.Assert(errors.Is(err, ErrJobFailed), qt.IsTrue)
+	c.Assert(job.ExtractRefreshJob.Notes, qt.Equals, "connection refused")
+	c.Assert(polls, qt.Equals, 3)
+}
+
+func TestJobsWaitPollIntervals(t *testing.T) {
+	tests := []struct {
+		desc     string
+		initial  time.Duration
+		max      time.Duration
+		timeout  time.Duration
+		maxPolls int
+	}{
+		// A non-positive interval falls back to the one second default, so
+		// the job isn't polled before the context expires.
+		{desc: "non-positive interval", initial: 0, max: -time.Second, timeout: 50 * time.Millisecond, maxPolls: 0},
+		// A maximum below the initial interval is raised to it instead of
+		// polling without delay.
+		{desc: "maximum below interval", initial: 10 * time.Millisecond, max: 0, timeout: 50 * time.Millisecond, maxPolls: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			polls := 0
+			client := newTestClient(t, "/api/3.4/sites/site-id/jobs/job-id", func(w http.ResponseWriter, r *http.Request) {
+				polls++
+				_, _ = w.Write([]byte(`{"job": {"id": "job-id", "progress": "50"}}`))
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			_, err := client.Jobs.Wait(ctx, "job-id", WithPollInterval(tt.initial, tt.max))
+			c.Assert(errors.Is(err, context.DeadlineExceeded), qt.IsTrue)
+			c.Assert(polls <= tt.maxPolls, qt.IsTrue, qt.Commentf("%d polls", polls))
+		})
+	}
+}
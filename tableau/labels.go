@@ -0,0 +1,361 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const labelsMinAPIVersion = "3.21"
+
+// LabelValue represents a label that can be applied to assets, i.e;
+// "Deprecated". Built in values, such as the data quality warning types, can't
+// be deleted.
+type LabelValue struct {
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Active      bool   `json:"active"`
+	// ElevatedDefault is whether labels with this value are shown with a
+	// higher visibility by default.
+	ElevatedDefault bool `json:"elevatedDefault"`
+	BuiltIn         bool `json:"builtIn"`
+}
+
+// LabelValueRequest encapsulates the request for creating or updating a label
+// value. Empty fields of an update are left unchanged on the server.
+type LabelValueRequest struct {
+	Name            string `json:"name,omitempty"`
+	Category        string `json:"category,omitempty"`
+	Description     string `json:"description,omitempty"`
+	Active          *bool  `json:"active,omitempty"`
+	ElevatedDefault *bool  `json:"elevatedDefault,omitempty"`
+}
+
+// LabelCategory represents a category grouping label values.
+type LabelCategory struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	BuiltIn     bool   `json:"builtIn"`
+}
+
+// LabelAsset identifies an asset labels are applied to, i.e; a table or a
+// data source.
+type LabelAsset struct {
+	ContentType DataQualityWarningContentType `json:"contentType"`
+	ID          string                        `json:"id"`
+}
+
+// Label represents a label value applied to an asset.
+type Label struct {
+	ID          string                        `json:"id"`
+	Value       string                        `json:"value"`
+	Category    string                        `json:"category"`
+	Message     string                        `json:"message"`
+	Active      bool                          `json:"active"`
+	Elevated    bool                          `json:"elevated"`
+	ContentType DataQualityWarningContentType `json:"contentType"`
+	ContentID   string                        `json:"contentId"`
+	Owner       struct {
+		ID string `json:"id"`
+	} `json:"owner"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ApplyLabelRequest encapsulates the label applied to assets by Labels.Apply.
+type ApplyLabelRequest struct {
+	Value    string `json:"value"`
+	Message  string `json:"message,omitempty"`
+	Active   *bool  `json:"active,omitempty"`
+	Elevated *bool  `json:"elevated,omitempty"`
+}
+
+type labelValueResponse struct {
+	LabelValue *LabelValue `json:"labelValue"`
+}
+
+type queryLabelValuesResponse struct {
+	LabelValues struct {
+		LabelValue []*LabelValue `json:"labelValue"`
+	} `json:"labelValueList"`
+}
+
+type labelCategoryResponse struct {
+	LabelCategory *LabelCategory `json:"labelCategory"`
+}
+
+type queryLabelCategoriesResponse struct {
+	LabelCategories struct {
+		LabelCategory []*LabelCategory `json:"labelCategory"`
+	} `json:"labelCategoryList"`
+}
+
+type labelsResponse struct {
+	Labels struct {
+		Label []*Label `json:"label"`
+	} `json:"labelList"`
+}
+
+// contentList is the wire representation of a list of assets.
+type contentList struct {
+	Content []LabelAsset `json:"content"`
+}
+
+type labelsService struct {
+	client *Client
+}
+
+func (ls *labelsService) CreateValue(ctx context.Context, createReq *LabelValueRequest) (*LabelValue, error) {
+	path := fmt.Sprintf("sites/%s/labelValues", ls.client.SiteID)
+	return ls.writeValue(ctx, http.MethodPost, path, createReq, "create label value")
+}
+
+func (ls *labelsService) UpdateValue(ctx context.Context, name string, updateReq *LabelValueRequest) (*LabelValue, error) {
+	path := fmt.Sprintf("sites/%s/labelValues/%s", ls.client.SiteID, url.PathEscape(name))
+	return ls.writeValue(ctx, http.MethodPut, path, updateReq, "update label value")
+}
+
+func (ls *labelsService) writeValue(ctx context.Context, method, path string, valueReq *LabelValueRequest, action string) (*LabelValue, error) {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	request := struct {
+		LabelValue *LabelValueRequest `json:"labelValue"`
+	}{
+		LabelValue: valueReq,
+	}
+
+	req, err := ls.client.newRequest(method, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for "+action)
+	}
+
+	resp := &labelValueResponse{}
+	err = ls.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.LabelValue, nil
+}
+
+func (ls *labelsService) QueryValues(ctx context.Context) ([]*LabelValue, error) {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/labelValues", ls.client.SiteID)
+	req, err := ls.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query label values")
+	}
+
+	resp := &queryLabelValuesResponse{}
+	err = ls.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.LabelValues.LabelValue, nil
+}
+
+func (ls *labelsService) GetValue(ctx context.Context, name string) (*LabelValue, error) {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/labelValues/%s", ls.client.SiteID, url.PathEscape(name))
+	req, err := ls.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get label value")
+	}
+
+	resp := &labelValueResponse{}
+	err = ls.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.LabelValue, nil
+}
+
+func (ls *labelsService) DeleteValue(ctx context.Context, name string) error {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/labelValues/%s", ls.client.SiteID, url.PathEscape(name))
+	req, err := ls.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete label value")
+	}
+	err = ls.client.do(ctx, req, nil)
+	return err
+}
+
+func (ls *labelsService) CreateCategory(ctx context.Context, category *LabelCategory) (*LabelCategory, error) {
+	path := fmt.Sprintf("sites/%s/labelCategories", ls.client.SiteID)
+	return ls.writeCategory(ctx, http.MethodPost, path, category, "create label category")
+}
+
+// UpdateCategory updates the label category with the given name, i.e; to
+// rename it or change its description.
+func (ls *labelsService) UpdateCategory(ctx context.Context, name string, category *LabelCategory) (*LabelCategory, error) {
+	path := fmt.Sprintf("sites/%s/labelCategories/%s", ls.client.SiteID, url.PathEscape(name))
+	return ls.writeCategory(ctx, http.MethodPut, path, category, "update label category")
+}
+
+func (ls *labelsService) writeCategory(ctx context.Context, method, path string, category *LabelCategory, action string) (*LabelCategory, error) {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	request := struct {
+		LabelCategory struct {
+			Name        string `json:"name,omitempty"`
+			Description string `json:"description,omitempty"`
+		} `json:"labelCategory"`
+	}{}
+	request.LabelCategory.Name = category.Name
+	request.LabelCategory.Description = category.Description
+
+	req, err := ls.client.newRequest(method, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for "+action)
+	}
+
+	resp := &labelCategoryResponse{}
+	err = ls.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.LabelCategory, nil
+}
+
+func (ls *labelsService) QueryCategories(ctx context.Context) ([]*LabelCategory, error) {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/labelCategories", ls.client.SiteID)
+	req, err := ls.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query label categories")
+	}
+
+	resp := &queryLabelCategoriesResponse{}
+	err = ls.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.LabelCategories.LabelCategory, nil
+}
+
+func (ls *labelsService) DeleteCategory(ctx context.Context, name string) error {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/labelCategories/%s", ls.client.SiteID, url.PathEscape(name))
+	req, err := ls.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete label category")
+	}
+	err = ls.client.do(ctx, req, nil)
+	return err
+}
+
+// Apply applies the label to each of assets, replacing the label of the same
+// category they may already have, and returns the applied labels.
+func (ls *labelsService) Apply(ctx context.Context, assets []LabelAsset, label *ApplyLabelRequest) ([]*Label, error) {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/labels", ls.client.SiteID)
+
+	request := struct {
+		ContentList contentList        `json:"contentList"`
+		Label       *ApplyLabelRequest `json:"label"`
+	}{
+		ContentList: contentList{Content: assets},
+		Label:       label,
+	}
+
+	req, err := ls.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for apply labels")
+	}
+
+	resp := &labelsResponse{}
+	err = ls.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Labels.Label, nil
+}
+
+// Query returns the labels applied to each of assets.
+func (ls *labelsService) Query(ctx context.Context, assets []LabelAsset) ([]*Label, error) {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/labels", ls.client.SiteID)
+
+	request := struct {
+		ContentList contentList `json:"contentList"`
+	}{
+		ContentList: contentList{Content: assets},
+	}
+
+	req, err := ls.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query labels")
+	}
+
+	resp := &labelsResponse{}
+	err = ls.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Labels.Label, nil
+}
+
+// Remove removes the labels of the given category from each of assets.
+func (ls *labelsService) Remove(ctx context.Context, assets []LabelAsset, category string) error {
+	err := ls.client.requireAPIVersion(labelsMinAPIVersion, "labels")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/labels", ls.client.SiteID)
+
+	request := struct {
+		ContentList contentList `json:"contentList"`
+		Label       struct {
+			Category string `json:"category"`
+		} `json:"label"`
+	}{
+		ContentList: contentList{Content: assets},
+	}
+	request.Label.Category = category
+
+	req, err := ls.client.newRequest(http.MethodDelete, path, request)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for remove labels")
+	}
+	err = ls.client.do(ctx, req, nil)
+	return err
+}
@@ -0,0 +1,103 @@
+package tableau
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+var testLabelAssets = []LabelAsset{
+	{ContentType: DataQualityWarningContentTable, ID: "table-id"},
+	{ContentType: DataQualityWarningContentDataSource, ID: "ds-id"},
+}
+
+var testLabelContentList = map[string]interface{}{
+	"content": []interface{}{
+		map[string]interface{}{"contentType": "table", "id": "table-id"},
+		map[string]interface{}{"contentType": "datasource", "id": "ds-id"},
+	},
+}
+
+func TestLabels(t *testing.T) {
+	active := true
+
+	tests := []struct {
+		desc       string
+		wantMethod string
+		wantBody   map[string]interface{}
+		call       func(*Client) ([]*Label, error)
+	}{
+		{
+			desc:       "apply",
+			wantMethod: http.MethodPut,
+			wantBody: map[string]interface{}{
+				"contentList": testLabelContentList,
+				"label": map[string]interface{}{
+					"value":   "Deprecated",
+					"message": "use sales_v2",
+					"active":  true,
+				},
+			},
+			call: func(client *Client) ([]*Label, error) {
+				return client.Labels.Apply(context.Background(), testLabelAssets, &ApplyLabelRequest{
+					Value:   "Deprecated",
+					Message: "use sales_v2",
+					Active:  &active,
+				})
+			},
+		},
+		{
+			desc:       "query",
+			wantMethod: http.MethodPost,
+			wantBody: map[string]interface{}{
+				"contentList": testLabelContentList,
+			},
+			call: func(client *Client) ([]*Label, error) {
+				return client.Labels.Query(context.Background(), testLabelAssets)
+			},
+		},
+		{
+			desc:       "remove",
+			wantMethod: http.MethodDelete,
+			wantBody: map[string]interface{}{
+				"contentList": testLabelContentList,
+				"label":       map[string]interface{}{"category": "Warning"},
+			},
+			call: func(client *Client) ([]*Label, error) {
+				return nil, client.Labels.Remove(context.Background(), testLabelAssets, "Warning")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			client := newTestClient(t, "/api/3.21/sites/site-id/labels", func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, tt.wantMethod)
+				var body map[string]interface{}
+				err := json.NewDecoder(r.Body).Decode(&body)
+				c.Check(err, qt.IsNil)
+				c.Check(body, qt.DeepEquals, tt.wantBody)
+
+				if r.Method == http.MethodDelete {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				_, _ = w.Write([]byte(`{"labelList": {"label": [
+					{"id": "label-id", "value": "Deprecated", "category": "Warning", "contentType": "table", "contentId": "table-id"}
+				]}}`))
+			}, WithAPIVersion("3.21"))
+
+			labels, err := tt.call(client)
+			c.Assert(err, qt.IsNil)
+			if tt.wantMethod != http.MethodDelete {
+				c.Assert(labels, qt.HasLen, 1)
+				c.Assert(labels[0].ContentType, qt.Equals, DataQualityWarningContentTable)
+				c.Assert(labels[0].Category, qt.Equals, "Warning")
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+const linkedTasksMinAPIVersion = "3.15"
+
+// LinkedTask represents a chain of flow runs executed one after the other.
+type LinkedTask struct {
+	ID       string    `json:"id"`
+	NumSteps int       `json:"numSteps,string"`
+	Schedule *Schedule `json:"schedule"`
+	Steps    struct {
+		Step []*LinkedTaskStep `json:"linkedTaskStep"`
+	} `json:"linkedTaskSteps"`
+}
+
+// LinkedTaskStep is a single flow run of a linked task.
+type LinkedTaskStep struct {
+	ID         string `json:"id"`
+	StepNumber int    `json:"stepNumber,string"`
+	// StopDownstreamTasksOnFailure stops the following steps when this one
+	// fails.
+	StopDownstreamTasksOnFailure bool `json:"stopDownstreamTasksOnFailure"`
+	Task                         struct {
+		FlowRun *struct {
+			ID   string `json:"id"`
+			Flow struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"flow"`
+		} `json:"flowRun"`
+	} `json:"task"`
+}
+
+// LinkedTaskJob is the job running a linked task.
+type LinkedTaskJob struct {
+	ID           string `json:"id"`
+	LinkedTaskID string `json:"linkedTaskId"`
+}
+
+type linkedTaskResponse struct {
+	LinkedTask *LinkedTask `json:"linkedTask"`
+}
+
+type queryLinkedTasksResponse struct {
+	LinkedTasks struct {
+		LinkedTask []*LinkedTask `json:"linkedTask"`
+	} `json:"linkedTasks"`
+}
+
+type linkedTaskJobResponse struct {
+	LinkedTaskJob *LinkedTaskJob `json:"linkedTaskJob"`
+}
+
+type linkedTasksService struct {
+	client *Client
+}
+
+func (lts *linkedTasksService) Query(ctx context.Context) ([]*LinkedTask, error) {
+	err := lts.client.requireAPIVersion(linkedTasksMinAPIVersion, "linked tasks")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/tasks/linked", lts.client.SiteID)
+	req, err := lts.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query linked tasks")
+	}
+
+	resp := &queryLinkedTasksResponse{}
+	err = lts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.LinkedTasks.LinkedTask, nil
+}
+
+func (lts *linkedTasksService) Get(ctx context.Context, id string) (*LinkedTask, error) {
+	err := lts.client.requireAPIVersion(linkedTasksMinAPIVersion, "linked tasks")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/tasks/linked/%s", lts.client.SiteID, id)
+	req, err := lts.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get linked task")
+	}
+
+	resp := &linkedTaskResponse{}
+	err = lts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.LinkedTask, nil
+}
+
+// RunNow runs the linked task outside of its schedule and returns the job
+// running it.
+func (lts *linkedTasksService) RunNow(ctx context.Context, id string) (*LinkedTaskJob, error) {
+	err := lts.client.requireAPIVersion(linkedTasksMinAPIVersion, "linked tasks")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/tasks/linked/%s/runNow", lts.client.SiteID, id)
+	req, err := lts.client.newRequest(http.MethodPost, path, struct{}{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for run linked task")
+	}
+
+	resp := &linkedTaskJobResponse{}
+	err = lts.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.LinkedTaskJob, nil
+}
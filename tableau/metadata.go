@@ -0,0 +1,195 @@
+package tableau
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+)
+
+// metadataPath is the path of the Metadata API GraphQL endpoint relative to
+// the versioned REST API base URL, which the Metadata API doesn't share.
+const metadataPath = "../metadata/graphql"
+
+// MetadataError is returned when the Metadata API answers a query with
+// GraphQL errors. Data of the response, if any, is still decoded.
+type MetadataError struct {
+	Errors []struct {
+		Message string        `json:"message"`
+		Path    []interface{} `json:"path"`
+	}
+}
+
+// Error returns the string representation of the error.
+func (e *MetadataError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Message)
+	}
+	return "metadata query failed: " + strings.Join(msgs, "; ")
+}
+
+// MetadataDataSource is a published data source as described by the Metadata
+// API.
+type MetadataDataSource struct {
+	// LUID is the id of the data source in the REST API.
+	LUID        string `json:"luid"`
+	Name        string `json:"name"`
+	ProjectName string `json:"projectName"`
+	IsCertified bool   `json:"isCertified"`
+	HasExtracts bool   `json:"hasExtracts"`
+}
+
+type metadataService struct {
+	client *Client
+}
+
+// Query runs a GraphQL query against the Metadata API with the session of the
+// client and decodes the data of the response into out.
+func (ms *metadataService) Query(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	request := struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{
+		Query:     query,
+		Variables: variables,
+	}
+
+	req, err := ms.client.newRequest(http.MethodPost, metadataPath, request)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for metadata query")
+	}
+
+	resp := &struct {
+		Data json.RawMessage `json:"data"`
+		MetadataError
+	}{}
+	err = ms.client.do(ctx, req, &resp)
+	if err != nil {
+		return err
+	}
+
+	if out != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		err = json.Unmarshal(resp.Data, out)
+		if err != nil {
+			return errors.Wrap(err, "error decoding metadata query data")
+		}
+	}
+	if len(resp.Errors) > 0 {
+		return &resp.MetadataError
+	}
+	return nil
+}
+
+// PublishedDataSources returns the published data sources of the site.
+func (ms *metadataService) PublishedDataSources(ctx context.Context) ([]*MetadataDataSource, error) {
+	const query = `query publishedDataSources {
+  publishedDatasources {
+    luid
+    name
+    projectName
+    isCertified
+    hasExtracts
+  }
+}`
+
+	data := &struct {
+		PublishedDatasources []*MetadataDataSource `json:"publishedDatasources"`
+	}{}
+	err := ms.Query(ctx, query, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	return data.PublishedDatasources, nil
+}
+
+// MetadataWorkbook is a workbook as described by the Metadata API.
+type MetadataWorkbook struct {
+	// LUID is the id of the workbook in the REST API.
+	LUID        string `json:"luid"`
+	Name        string `json:"name"`
+	ProjectName string `json:"projectName"`
+	Owner       struct {
+		Username string `json:"username"`
+	} `json:"owner"`
+}
+
+// MetadataTable is a database table as described by the Metadata API.
+type MetadataTable struct {
+	// LUID is the id of the table in the REST API.
+	LUID     string `json:"luid"`
+	Name     string `json:"name"`
+	Schema   string `json:"schema"`
+	FullName string `json:"fullName"`
+	Database struct {
+		LUID           string `json:"luid"`
+		Name           string `json:"name"`
+		ConnectionType string `json:"connectionType"`
+	} `json:"database"`
+}
+
+// DownstreamWorkbooks returns the workbooks that use the published data source
+// with the given REST API id.
+func (ms *metadataService) DownstreamWorkbooks(ctx context.Context, dataSourceLUID string) ([]*MetadataWorkbook, error) {
+	const query = `query downstreamWorkbooks($luid: String!) {
+  publishedDatasources(filter: {luid: $luid}) {
+    downstreamWorkbooks {
+      luid
+      name
+      projectName
+      owner {
+        username
+      }
+    }
+  }
+}`
+
+	data := &struct {
+		PublishedDatasources []struct {
+			DownstreamWorkbooks []*MetadataWorkbook `json:"downstreamWorkbooks"`
+		} `json:"publishedDatasources"`
+	}{}
+	err := ms.Query(ctx, query, map[string]interface{}{"luid": dataSourceLUID}, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.PublishedDatasources) == 0 {
+		return nil, errors.Errorf("published data source %q not found in metadata", dataSourceLUID)
+	}
+	return data.PublishedDatasources[0].DownstreamWorkbooks, nil
+}
+
+// UpstreamTables returns the database tables the workbook with the given REST
+// API id depends on, directly or through data sources.
+func (ms *metadataService) UpstreamTables(ctx context.Context, workbookLUID string) ([]*MetadataTable, error) {
+	const query = `query upstreamTables($luid: String!) {
+  workbooks(filter: {luid: $luid}) {
+    upstreamTables {
+      luid
+      name
+      schema
+      fullName
+      database {
+        luid
+        name
+        connectionType
+      }
+    }
+  }
+}`
+
+	data := &struct {
+		Workbooks []struct {
+			UpstreamTables []*MetadataTable `json:"upstreamTables"`
+		} `json:"workbooks"`
+	}{}
+	err := ms.Query(ctx, query, map[string]interface{}{"luid": workbookLUID}, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(data.Workbooks) == 0 {
+		return nil, errors.Errorf("workbook %q not found in metadata", workbookLUID)
+	}
+	return data.Workbooks[0].UpstreamTables, nil
+}
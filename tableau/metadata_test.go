@@ -0,0 +1,31 @@
+package tableau
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMetadataQuery(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/metadata/graphql", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Header.Get("X-Tableau-Auth"), qt.Equals, "token")
+		_, _ = w.Write([]byte(`{
+			"data": {"publishedDatasources": [{"luid": "ds-id", "name": "Sales", "isCertified": true}]},
+			"errors": [{"message": "field hasExtracts is unavailable"}]
+		}`))
+	})
+
+	data := &struct {
+		PublishedDatasources []*MetadataDataSource `json:"publishedDatasources"`
+	}{}
+	err := client.Metadata.Query(context.Background(), "query { publishedDatasources { luid } }", nil, data)
+
+	var mErr *MetadataError
+	c.Assert(errors.As(err, &mErr), qt.IsTrue)
+	c.Assert(err, qt.ErrorMatches, "metadata query failed: field hasExtracts is unavailable")
+	c.Assert(data.PublishedDatasources, qt.DeepEquals, []*MetadataDataSource{{LUID: "ds-id", Name: "Sales", IsCertified: true}})
+}
@@ -0,0 +1,241 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+// Permissions represents the permission rules set on a piece of content.
+type Permissions struct {
+	GranteeCapabilities []*GranteeCapabilities `json:"granteeCapabilities"`
+}
+
+// GranteeCapabilities represents the capabilities granted to or denied from a
+// single user or group. Exactly one of User and Group is set.
+type GranteeCapabilities struct {
+	User         *Grantee `json:"user,omitempty"`
+	Group        *Grantee `json:"group,omitempty"`
+	Capabilities struct {
+		Capability []*Capability `json:"capability"`
+	} `json:"capabilities"`
+}
+
+// Grantee identifies the user or group a permission rule applies to.
+type Grantee struct {
+	ID string `json:"id"`
+}
+
+// Capability represents a single permission, i.e; Read, with its mode, Allow
+// or Deny.
+type Capability struct {
+	Name CapabilityName `json:"name"`
+	Mode CapabilityMode `json:"mode"`
+}
+
+// CapabilityName represents the name of a permission capability.
+type CapabilityName string
+
+const (
+	CapabilityAddComment           CapabilityName = "AddComment"
+	CapabilityChangeHierarchy      CapabilityName = "ChangeHierarchy"
+	CapabilityChangePermissions    CapabilityName = "ChangePermissions"
+	CapabilityConnect              CapabilityName = "Connect"
+	CapabilityCreateRefreshMetrics CapabilityName = "CreateRefreshMetrics"
+	CapabilityDelete               CapabilityName = "Delete"
+	CapabilityExecute              CapabilityName = "Execute"
+	CapabilityExportData           CapabilityName = "ExportData"
+	CapabilityExportImage          CapabilityName = "ExportImage"
+	CapabilityExportXML            CapabilityName = "ExportXml"
+	CapabilityExtractRefresh       CapabilityName = "ExtractRefresh"
+	CapabilityFilter               CapabilityName = "Filter"
+	CapabilityProjectLeader        CapabilityName = "ProjectLeader"
+	CapabilityRead                 CapabilityName = "Read"
+	CapabilityRunExplainData       CapabilityName = "RunExplainData"
+	CapabilitySaveAs               CapabilityName = "SaveAs"
+	CapabilityShareView            CapabilityName = "ShareView"
+	CapabilityViewComments         CapabilityName = "ViewComments"
+	CapabilityViewUnderlyingData   CapabilityName = "ViewUnderlyingData"
+	CapabilityWebAuthoring         CapabilityName = "WebAuthoring"
+	CapabilityWrite                CapabilityName = "Write"
+)
+
+// CapabilityMode represents whether a capability is granted or denied.
+type CapabilityMode string
+
+const (
+	CapabilityModeAllow CapabilityMode = "Allow"
+	CapabilityModeDeny  CapabilityMode = "Deny"
+)
+
+// PermissionSet builds the Permissions accepted by the AddPermissions and
+// AddDefaultPermissions methods, i.e;
+//
+//	NewPermissionSet().Group(id).Allow(CapabilityRead, CapabilityExportImage).Build()
+//
+// Allow and Deny apply to the user or group selected last.
+type PermissionSet struct {
+	rules   []*GranteeCapabilities
+	current *GranteeCapabilities
+	err     error
+}
+
+// NewPermissionSet returns an empty PermissionSet.
+func NewPermissionSet() *PermissionSet {
+	return &PermissionSet{}
+}
+
+// User selects the user with the given id as the grantee of the following
+// capabilities.
+func (ps *PermissionSet) User(id string) *PermissionSet {
+	return ps.grantee(&GranteeCapabilities{User: &Grantee{ID: id}})
+}
+
+// Group selects the group with the given id as the grantee of the following
+// capabilities.
+func (ps *PermissionSet) Group(id string) *PermissionSet {
+	return ps.grantee(&GranteeCapabilities{Group: &Grantee{ID: id}})
+}
+
+func (ps *PermissionSet) grantee(rule *GranteeCapabilities) *PermissionSet {
+	for _, r := range ps.rules {
+		if sameGrantee(r, rule) {
+			ps.current = r
+			return ps
+		}
+	}
+	ps.rules = append(ps.rules, rule)
+	ps.current = rule
+	return ps
+}
+
+// Allow grants capabilities to the selected grantee.
+func (ps *PermissionSet) Allow(capabilities ...CapabilityName) *PermissionSet {
+	return ps.set(CapabilityModeAllow, capabilities)
+}
+
+// Deny denies capabilities to the selected grantee.
+func (ps *PermissionSet) Deny(capabilities ...CapabilityName) *PermissionSet {
+	return ps.set(CapabilityModeDeny, capabilities)
+}
+
+// set adds capabilities with the given mode to the current rule, replacing
+// the mode of capabilities that were already set.
+func (ps *PermissionSet) set(mode CapabilityMode, capabilities []CapabilityName) *PermissionSet {
+	if ps.err != nil {
+		return ps
+	}
+	if ps.current == nil {
+		ps.err = errors.New("no user or group selected for capabilities")
+		return ps
+	}
+
+next:
+	for _, name := range capabilities {
+		for _, c := range ps.current.Capabilities.Capability {
+			if c.Name == name {
+				c.Mode = mode
+				continue next
+			}
+		}
+		ps.current.Capabilities.Capability = append(ps.current.Capabilities.Capability, &Capability{Name: name, Mode: mode})
+	}
+	return ps
+}
+
+// Build returns the permissions, or the first error encountered while
+// building them.
+func (ps *PermissionSet) Build() (*Permissions, error) {
+	if ps.err != nil {
+		return nil, ps.err
+	}
+	return &Permissions{GranteeCapabilities: ps.rules}, nil
+}
+
+func sameGrantee(a, b *GranteeCapabilities) bool {
+	switch {
+	case a.User != nil && b.User != nil:
+		return a.User.ID == b.User.ID
+	case a.Group != nil && b.Group != nil:
+		return a.Group.ID == b.Group.ID
+	}
+	return false
+}
+
+// granteePath returns the path segment identifying the grantee of the rule.
+func (gc *GranteeCapabilities) granteePath() (string, error) {
+	switch {
+	case gc.User != nil:
+		return "users/" + gc.User.ID, nil
+	case gc.Group != nil:
+		return "groups/" + gc.Group.ID, nil
+	default:
+		return "", errors.New("permission rule has neither a user nor a group grantee")
+	}
+}
+
+type permissionsResponse struct {
+	Permissions *Permissions `json:"permissions"`
+}
+
+// permissions returns the permissions of the content whose permissions live
+// at path.
+func (c *Client) permissions(ctx context.Context, path string) (*Permissions, error) {
+	req, err := c.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query permissions")
+	}
+
+	resp := &permissionsResponse{}
+	err = c.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Permissions, nil
+}
+
+// addPermissions adds the rules in permissions to the content whose
+// permissions live at path and returns the rules that were added.
+func (c *Client) addPermissions(ctx context.Context, path string, permissions *Permissions) (*Permissions, error) {
+	request := permissionsResponse{
+		Permissions: permissions,
+	}
+
+	req, err := c.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for add permissions")
+	}
+
+	resp := &permissionsResponse{}
+	err = c.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Permissions, nil
+}
+
+// deletePermission removes every capability of rule from the content whose
+// permissions live at path. Tableau deletes capabilities one at a time, so
+// a failure may leave the rule partially deleted.
+func (c *Client) deletePermission(ctx context.Context, path string, rule *GranteeCapabilities) error {
+	grantee, err := rule.granteePath()
+	if err != nil {
+		return err
+	}
+
+	for _, capability := range rule.Capabilities.Capability {
+		req, err := c.newRequest(http.MethodDelete, fmt.Sprintf("%s/%s/%s/%s", path, grantee, capability.Name, capability.Mode), nil)
+		if err != nil {
+			return errors.Wrap(err, "error creating request for delete permission")
+		}
+
+		err = c.do(ctx, req, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
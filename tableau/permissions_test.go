@@ -0,0 +1,87 @@
+package tableau
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestPermissionSet(t *testing.T) {
+	c := qt.New(t)
+
+	permissions, err := NewPermissionSet().
+		Group("group-id").Allow(CapabilityRead, CapabilityExportImage).
+		User("user-id").Deny(CapabilityExportData).
+		Group("group-id").Deny(CapabilityExportImage).
+		Build()
+	c.Assert(err, qt.IsNil)
+
+	want := &GranteeCapabilities{Group: &Grantee{ID: "group-id"}}
+	want.Capabilities.Capability = []*Capability{
+		{Name: CapabilityRead, Mode: CapabilityModeAllow},
+		{Name: CapabilityExportImage, Mode: CapabilityModeDeny},
+	}
+	user := &GranteeCapabilities{User: &Grantee{ID: "user-id"}}
+	user.Capabilities.Capability = []*Capability{
+		{Name: CapabilityExportData, Mode: CapabilityModeDeny},
+	}
+	c.Assert(permissions, qt.DeepEquals, &Permissions{GranteeCapabilities: []*GranteeCapabilities{want, user}})
+
+	_, err = NewPermissionSet().Allow(CapabilityRead).Build()
+	c.Assert(err, qt.ErrorMatches, "no user or group selected for capabilities")
+}
+
+func TestResolveEffectivePermissions(t *testing.T) {
+	content, err := NewPermissionSet().
+		Group("all-users").Allow(CapabilityRead, CapabilityExportData).
+		Group("contractors").Deny(CapabilityExportData).
+		User("user-id").Allow(CapabilityExportData).Deny(CapabilityWrite).
+		Build()
+	qt.Assert(t, err, qt.IsNil)
+	defaults, err := NewPermissionSet().Group("all-users").Allow(CapabilityWrite).Build()
+	qt.Assert(t, err, qt.IsNil)
+
+	tests := []struct {
+		desc    string
+		rules   *PermissionRules
+		allowed []CapabilityName
+		denied  []CapabilityName
+	}{
+		{
+			desc:    "group deny wins over group allow",
+			rules:   &PermissionRules{UserID: "other", GroupIDs: []string{"all-users", "contractors"}, Content: content},
+			allowed: []CapabilityName{CapabilityRead},
+			denied:  []CapabilityName{CapabilityExportData, CapabilityWrite},
+		},
+		{
+			desc:    "user rule wins over group rules",
+			rules:   &PermissionRules{UserID: "user-id", GroupIDs: []string{"all-users", "contractors"}, Content: content},
+			allowed: []CapabilityName{CapabilityRead, CapabilityExportData},
+			denied:  []CapabilityName{CapabilityWrite},
+		},
+		{
+			desc:    "locked project uses defaults",
+			rules:   &PermissionRules{UserID: "user-id", GroupIDs: []string{"all-users"}, Content: content, ProjectDefaults: defaults, Locked: true},
+			allowed: []CapabilityName{CapabilityWrite},
+			denied:  []CapabilityName{CapabilityRead},
+		},
+		{
+			desc:    "owner holds everything",
+			rules:   &PermissionRules{UserID: "user-id", Content: content, Owner: true},
+			allowed: []CapabilityName{CapabilityWrite, CapabilityDelete},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			effective := ResolveEffectivePermissions(tt.rules)
+			for _, name := range tt.allowed {
+				c.Check(effective.Allowed(name), qt.IsTrue, qt.Commentf("%s", name))
+			}
+			for _, name := range tt.denied {
+				c.Check(effective.Allowed(name), qt.IsFalse, qt.Commentf("%s", name))
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -19,27 +20,95 @@ const (
 	ProjectContentPermissionLockedToProjectWithoutNested ProjectContentPermission = "LockedToProjectWithoutNested"
 )
 
+// ErrProjectNotFound is returned when the requested project doesn't exist.
+var ErrProjectNotFound = errors.New("project not found")
+
+// errCodeProjectNotFound is the error code the server replies with for a
+// missing project.
+const errCodeProjectNotFound = "404005"
+
+// Content types whose default permissions are set on a project.
+const (
+	DefaultPermissionsWorkbooks   = "workbooks"
+	DefaultPermissionsDataSources = "datasources"
+	DefaultPermissionsFlows       = "flows"
+	DefaultPermissionsLenses      = "lenses"
+	DefaultPermissionsMetrics     = "metrics"
+	DefaultPermissionsDatabases   = "databases"
+	DefaultPermissionsTables      = "tables"
+)
+
+// defaultPermissionsPath validates contentType and returns the path of the
+// project's default permissions for it.
+func (ps *projectsService) defaultPermissionsPath(projectID, contentType string) (string, error) {
+	switch contentType {
+	case DefaultPermissionsWorkbooks, DefaultPermissionsDataSources, DefaultPermissionsFlows,
+		DefaultPermissionsLenses, DefaultPermissionsMetrics, DefaultPermissionsDatabases, DefaultPermissionsTables:
+	default:
+		return "", errors.Errorf("unsupported default permissions content type %q", contentType)
+	}
+
+	return fmt.Sprintf("sites/%s/projects/%s/default-permissions/%s", ps.client.SiteID, projectID, contentType), nil
+}
+
 type projectsService struct {
 	client *Client
 }
 
 func (ps *projectsService) Query(ctx context.Context, opts ...QueryOption) ([]*Project, error) {
-	path := fmt.Sprintf("sites/%s/projects", ps.client.SiteID)
-
-	queryOpts := &QueryOptions{
-		URLValues: &url.Values{},
+	resp, err := ps.query(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, opt := range opts {
-		err := opt(queryOpts)
-		if err != nil {
-			panic(err)
+	return resp.Projects.Project, nil
+}
+
+// QueryStream fetches projects page by page and emits them on the returned
+// channel as each page arrives. The project channel is closed once all pages
+// are fetched, the context is cancelled or a request fails. The error channel
+// then yields exactly one value, which is nil on success.
+func (ps *projectsService) QueryStream(ctx context.Context, opts ...QueryOption) (<-chan *Project, <-chan error) {
+	projects := make(chan *Project)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer close(projects)
+
+		for page := 1; ; page++ {
+			pageOpts := append(opts[:len(opts):len(opts)], WithPageNumber(page))
+			resp, err := ps.query(ctx, pageOpts)
+			if err != nil {
+				errc <- err
+				return
+			}
+
+			for _, p := range resp.Projects.Project {
+				select {
+				case projects <- p:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+
+			if len(resp.Projects.Project) == 0 || resp.Pagination.PageNumber*resp.Pagination.PageSize >= resp.Pagination.TotalAvailable {
+				errc <- nil
+				return
+			}
 		}
-	}
+	}()
 
-	if vals := queryOpts.URLValues.Encode(); vals != "" {
-		path += "?" + vals
+	return projects, errc
+}
+
+func (ps *projectsService) query(ctx context.Context, opts []QueryOption) (*queryProjectResponse, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/projects", ps.client.SiteID), opts)
+	if err != nil {
+		return nil, err
 	}
+
 	req, err := ps.client.newRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating request for query projects")
@@ -51,7 +120,7 @@ func (ps *projectsService) Query(ctx context.Context, opts ...QueryOption) ([]*P
 		return nil, err
 	}
 
-	return resp.Projects.Project, nil
+	return resp, nil
 }
 
 func (ps *projectsService) Create(ctx context.Context, createReq *CreateProjectRequest) (*Project, error) {
@@ -90,7 +159,7 @@ func (ps *projectsService) Update(ctx context.Context, updateReq *UpdateProjectR
 	resp := &createProjectResponse{}
 	err = ps.client.do(ctx, req, &resp)
 	if err != nil {
-		return nil, err
+		return nil, withSentinel(err, errCodeProjectNotFound, ErrProjectNotFound)
 	}
 	return resp.Project, nil
 }
@@ -104,11 +173,83 @@ func (ps *projectsService) Delete(ctx context.Context, deleteReq *DeleteProjectR
 	resp := &createProjectResponse{}
 	err = ps.client.do(ctx, req, &resp)
 	if err != nil {
-		return nil, err
+		return nil, withSentinel(err, errCodeProjectNotFound, ErrProjectNotFound)
 	}
 	return resp.Project, nil
 }
 
+// Permissions returns the explicit permissions set on the project.
+func (ps *projectsService) Permissions(ctx context.Context, projectID string) (*Permissions, error) {
+	path := fmt.Sprintf("sites/%s/projects/%s/permissions", ps.client.SiteID, projectID)
+	permissions, err := ps.client.permissions(ctx, path)
+	if err != nil {
+		return nil, withSentinel(err, errCodeProjectNotFound, ErrProjectNotFound)
+	}
+	return permissions, nil
+}
+
+// AddPermissions adds the rules in permissions to the project and returns the
+// rules that were added.
+func (ps *projectsService) AddPermissions(ctx context.Context, projectID string, permissions *Permissions) (*Permissions, error) {
+	path := fmt.Sprintf("sites/%s/projects/%s/permissions", ps.client.SiteID, projectID)
+	added, err := ps.client.addPermissions(ctx, path, permissions)
+	if err != nil {
+		return nil, withSentinel(err, errCodeProjectNotFound, ErrProjectNotFound)
+	}
+	return added, nil
+}
+
+// DeletePermission removes the capabilities of rule from the project.
+func (ps *projectsService) DeletePermission(ctx context.Context, projectID string, rule *GranteeCapabilities) error {
+	path := fmt.Sprintf("sites/%s/projects/%s/permissions", ps.client.SiteID, projectID)
+	err := ps.client.deletePermission(ctx, path, rule)
+	return withSentinel(err, errCodeProjectNotFound, ErrProjectNotFound)
+}
+
+// GetDefaultPermissions returns the default permissions of the project for
+// the given content type, which new content published to the project
+// inherits. contentType is one of the DefaultPermissions constants.
+func (ps *projectsService) GetDefaultPermissions(ctx context.Context, projectID, contentType string) (*Permissions, error) {
+	path, err := ps.defaultPermissionsPath(projectID, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := ps.client.permissions(ctx, path)
+	if err != nil {
+		return nil, withSentinel(err, errCodeProjectNotFound, ErrProjectNotFound)
+	}
+	return permissions, nil
+}
+
+// AddDefaultPermissions adds the rules in permissions to the default
+// permissions of the project for the given content type and returns the rules
+// that were added.
+func (ps *projectsService) AddDefaultPermissions(ctx context.Context, projectID, contentType string, permissions *Permissions) (*Permissions, error) {
+	path, err := ps.defaultPermissionsPath(projectID, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := ps.client.addPermissions(ctx, path, permissions)
+	if err != nil {
+		return nil, withSentinel(err, errCodeProjectNotFound, ErrProjectNotFound)
+	}
+	return added, nil
+}
+
+// DeleteDefaultPermission removes the capabilities of rule from the default
+// permissions of the project for the given content type.
+func (ps *projectsService) DeleteDefaultPermission(ctx context.Context, projectID, contentType string, rule *GranteeCapabilities) error {
+	path, err := ps.defaultPermissionsPath(projectID, contentType)
+	if err != nil {
+		return err
+	}
+
+	err = ps.client.deletePermission(ctx, path, rule)
+	return withSentinel(err, errCodeProjectNotFound, ErrProjectNotFound)
+}
+
 // QueryOptions are options for querying projects.
 type QueryOptions struct {
 	URLValues *url.Values
@@ -116,6 +257,25 @@ type QueryOptions struct {
 
 type QueryOption func(*QueryOptions) error
 
+// applyQueryOptions appends the URL parameters set by opts to path.
+func applyQueryOptions(path string, opts []QueryOption) (string, error) {
+	queryOpts := &QueryOptions{
+		URLValues: &url.Values{},
+	}
+
+	for _, opt := range opts {
+		err := opt(queryOpts)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if vals := queryOpts.URLValues.Encode(); vals != "" {
+		path += "?" + vals
+	}
+	return path, nil
+}
+
 // WithPageSize returns a QueryOption that sets the "pageSize" URL parameter.
 func WithPageSize(pageSize int) QueryOption {
 	return func(opt *QueryOptions) error {
@@ -156,6 +316,17 @@ func WithSortExpression(sortExp string) QueryOption {
 	}
 }
 
+// WithFields returns a QueryOption that sets the "fields" URL parameter, which
+// selects the fields returned for each item, i.e; "_default_,sheetType".
+func WithFields(fields ...string) QueryOption {
+	return func(opt *QueryOptions) error {
+		if len(fields) > 0 {
+			opt.URLValues.Set("fields", strings.Join(fields, ","))
+		}
+		return nil
+	}
+}
+
 // CreateProjectRequest encapsulates the request for creating a new project.
 type CreateProjectRequest struct {
 	ParentProjectId    string                   `json:"parentProjectId,omitempty"`
@@ -173,13 +344,16 @@ type DeleteProjectRequest struct {
 	ID string `json:"id"`
 }
 
+// Pagination describes the page returned by a query endpoint.
+type Pagination struct {
+	PageNumber     int `json:"pageNumber,string"`
+	PageSize       int `json:"pageSize,string"`
+	TotalAvailable int `json:"totalAvailable,string"`
+}
+
 type queryProjectResponse struct {
-	Pagination struct {
-		PageSize       string `json:"pageSize"`
-		PageNumber     string `json:"pageNumber"`
-		TotalAvailable string `json:"totalAvailabe"`
-	}
-	Projects struct {
+	Pagination Pagination
+	Projects   struct {
 		Project []*Project `json:"project"`
 	}
 }
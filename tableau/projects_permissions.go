@@ -0,0 +1,134 @@
+package tableau
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"sort"
+)
+
+// PermissionsTemplate is the set of permissions ApplyPermissionsRecursive
+// applies to every project of a tree.
+type PermissionsTemplate struct {
+	// Project holds the permissions set on the projects themselves.
+	Project *Permissions
+	// Defaults holds the default permissions set on the projects, keyed by
+	// one of the DefaultPermissions constants.
+	Defaults map[string]*Permissions
+}
+
+// PermissionsStep is a single change made, or planned in a dry run, by
+// ApplyPermissionsRecursive.
+type PermissionsStep struct {
+	ProjectID   string
+	ProjectName string
+	// ContentType is empty for the project permissions and one of the
+	// DefaultPermissions constants for default permissions.
+	ContentType string
+	Permissions *Permissions
+}
+
+// ApplyPermissionsOptions are options for ApplyPermissionsRecursive.
+type ApplyPermissionsOptions struct {
+	DryRun   bool
+	Progress func(done, total int, step *PermissionsStep)
+}
+
+type ApplyPermissionsOption func(*ApplyPermissionsOptions)
+
+// WithDryRun returns an ApplyPermissionsOption that only plans the changes
+// without sending them to the server.
+func WithDryRun() ApplyPermissionsOption {
+	return func(opt *ApplyPermissionsOptions) {
+		opt.DryRun = true
+	}
+}
+
+// WithProgress returns an ApplyPermissionsOption that calls fn after each
+// step is applied, or planned in a dry run.
+func WithProgress(fn func(done, total int, step *PermissionsStep)) ApplyPermissionsOption {
+	return func(opt *ApplyPermissionsOptions) {
+		opt.Progress = fn
+	}
+}
+
+// ApplyPermissionsRecursive adds the permissions of template to the project
+// with the given id and to all of its nested projects, and returns the steps
+// taken. Nested projects whose permissions are locked to an ancestor are
+// skipped, since the server derives their permissions from that ancestor.
+//
+// Steps are applied one at a time and the first failure stops the walk, in
+// which case the steps taken so far are returned along with the error.
+func (ps *projectsService) ApplyPermissionsRecursive(ctx context.Context, rootProjectID string, template *PermissionsTemplate, opts ...ApplyPermissionsOption) ([]*PermissionsStep, error) {
+	applyOpts := &ApplyPermissionsOptions{}
+	for _, opt := range opts {
+		opt(applyOpts)
+	}
+
+	tree, err := ps.tree(ctx, rootProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	contentTypes := make([]string, 0, len(template.Defaults))
+	for contentType := range template.Defaults {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	var steps []*PermissionsStep
+	for _, p := range tree {
+		if p.ControllingPermissionsProjectId != "" && p.ControllingPermissionsProjectId != p.ID {
+			continue
+		}
+		if template.Project != nil {
+			steps = append(steps, &PermissionsStep{ProjectID: p.ID, ProjectName: p.Name, Permissions: template.Project})
+		}
+		for _, contentType := range contentTypes {
+			steps = append(steps, &PermissionsStep{ProjectID: p.ID, ProjectName: p.Name, ContentType: contentType, Permissions: template.Defaults[contentType]})
+		}
+	}
+
+	for i, step := range steps {
+		if !applyOpts.DryRun {
+			if step.ContentType == "" {
+				_, err = ps.AddPermissions(ctx, step.ProjectID, step.Permissions)
+			} else {
+				_, err = ps.AddDefaultPermissions(ctx, step.ProjectID, step.ContentType, step.Permissions)
+			}
+			if err != nil {
+				return steps[:i], errors.Wrapf(err, "error applying permissions to project %q", step.ProjectName)
+			}
+		}
+		if applyOpts.Progress != nil {
+			applyOpts.Progress(i+1, len(steps), step)
+		}
+	}
+	return steps, nil
+}
+
+// tree returns the project with the given id followed by all of its nested
+// projects, parents before children.
+func (ps *projectsService) tree(ctx context.Context, rootProjectID string) ([]*Project, error) {
+	byID := map[string]*Project{}
+	children := map[string][]*Project{}
+
+	projects, errc := ps.QueryStream(ctx, WithPageSize(1000))
+	for p := range projects {
+		byID[p.ID] = p
+		children[p.ParentProjectId] = append(children[p.ParentProjectId], p)
+	}
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+
+	root, ok := byID[rootProjectID]
+	if !ok {
+		return nil, errors.Wrap(ErrProjectNotFound, rootProjectID)
+	}
+
+	tree := []*Project{root}
+	for i := 0; i < len(tree); i++ {
+		tree = append(tree, children[tree[i].ID]...)
+	}
+	return tree, nil
+}
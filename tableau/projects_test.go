@@ -0,0 +1,123 @@
+package tableau
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestProjectsQueryStream(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/projects", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("pageNumber")
+		fmt.Fprintf(w, `{
+			"pagination": {"pageNumber": "%s", "pageSize": "2", "totalAvailable": "3"},
+			"projects": {"project": [{"id": "%s-a"}, {"id": "%s-b"}]}
+		}`, page, page, page)
+	})
+
+	projects, errc := client.Projects.QueryStream(context.Background(), WithPageSize(2))
+
+	var ids []string
+	for p := range projects {
+		ids = append(ids, p.ID)
+	}
+	c.Assert(<-errc, qt.IsNil)
+	c.Assert(ids, qt.DeepEquals, []string{"1-a", "1-b", "2-a", "2-b"})
+}
+
+func TestProjectsNotFound(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/projects/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"summary": "Resource Not Found", "detail": "Project 'missing' could not be found.", "code": "404005"}}`))
+	})
+
+	_, err := client.Projects.Update(context.Background(), &UpdateProjectRequest{ID: "missing", Name: "name"})
+	c.Assert(errors.Is(err, ErrProjectNotFound), qt.IsTrue)
+
+	_, err = client.Projects.Delete(context.Background(), &DeleteProjectRequest{ID: "missing"})
+	c.Assert(errors.Is(err, ErrProjectNotFound), qt.IsTrue)
+
+	var tErr *Error
+	c.Assert(errors.As(err, &tErr), qt.IsTrue)
+	c.Assert(tErr.HTTPStatusCode, qt.Equals, http.StatusNotFound)
+}
+
+func TestProjectsDeletePermission(t *testing.T) {
+	c := qt.New(t)
+	var deleted []string
+	client := newTestClient(t, "/api/3.4/sites/site-id/projects/project-id/permissions/", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodDelete)
+		deleted = append(deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rule := &GranteeCapabilities{Group: &Grantee{ID: "group-id"}}
+	rule.Capabilities.Capability = []*Capability{
+		{Name: "Read", Mode: "Allow"},
+		{Name: "Write", Mode: "Deny"},
+	}
+	err := client.Projects.DeletePermission(context.Background(), "project-id", rule)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.DeepEquals, []string{
+		"/api/3.4/sites/site-id/projects/project-id/permissions/groups/group-id/Read/Allow",
+		"/api/3.4/sites/site-id/projects/project-id/permissions/groups/group-id/Write/Deny",
+	})
+
+	err = client.Projects.DeletePermission(context.Background(), "project-id", &GranteeCapabilities{})
+	c.Assert(err, qt.ErrorMatches, "permission rule has neither a user nor a group grantee")
+}
+
+func TestProjectsApplyPermissionsRecursive(t *testing.T) {
+	c := qt.New(t)
+	var puts []string
+	client := newTestClient(t, "/api/3.4/sites/site-id/projects", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/3.4/sites/site-id/projects":
+			_, _ = w.Write([]byte(`{
+				"pagination": {"pageNumber": "1", "pageSize": "1000", "totalAvailable": "4"},
+				"projects": {"project": [
+					{"id": "root", "name": "Root", "controllingPermissionsProjectId": "root"},
+					{"id": "child", "name": "Child", "parentProjectId": "root", "controllingPermissionsProjectId": "child"},
+					{"id": "locked", "name": "Locked", "parentProjectId": "child", "controllingPermissionsProjectId": "child"},
+					{"id": "other", "name": "Other"}
+				]}
+			}`))
+		case r.Method == http.MethodPut:
+			puts = append(puts, r.URL.Path)
+			_, _ = w.Write([]byte(`{"permissions": {}}`))
+		default:
+			c.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	permissions, err := NewPermissionSet().Group("group-id").Allow(CapabilityRead).Build()
+	c.Assert(err, qt.IsNil)
+	template := &PermissionsTemplate{
+		Project:  permissions,
+		Defaults: map[string]*Permissions{DefaultPermissionsWorkbooks: permissions},
+	}
+
+	var progress []int
+	steps, err := client.Projects.ApplyPermissionsRecursive(context.Background(), "root", template, WithDryRun(), WithProgress(func(done, total int, _ *PermissionsStep) {
+		progress = append(progress, done, total)
+	}))
+	c.Assert(err, qt.IsNil)
+	c.Assert(steps, qt.HasLen, 4)
+	c.Assert(progress, qt.DeepEquals, []int{1, 4, 2, 4, 3, 4, 4, 4})
+	c.Assert(puts, qt.HasLen, 0)
+
+	_, err = client.Projects.ApplyPermissionsRecursive(context.Background(), "root", template)
+	c.Assert(err, qt.IsNil)
+	c.Assert(puts, qt.DeepEquals, []string{
+		"/api/3.4/sites/site-id/projects/root/permissions",
+		"/api/3.4/sites/site-id/projects/root/default-permissions/workbooks",
+		"/api/3.4/sites/site-id/projects/child/permissions",
+		"/api/3.4/sites/site-id/projects/child/default-permissions/workbooks",
+	})
+}
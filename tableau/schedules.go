@@ -0,0 +1,306 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ScheduleInterval represents a single interval of a schedule. Only the field
+// relevant for the frequency of the schedule is set, i.e; WeekDay for a weekly
+// schedule.
+type ScheduleInterval struct {
+	Hours    string `json:"hours,omitempty"`
+	Minutes  string `json:"minutes,omitempty"`
+	WeekDay  string `json:"weekDay,omitempty"`
+	MonthDay string `json:"monthDay,omitempty"`
+}
+
+// ScheduleIntervals is the wire representation of a list of intervals.
+type ScheduleIntervals struct {
+	Interval []*ScheduleInterval `json:"interval"`
+}
+
+// ScheduleType represents the kind of tasks a schedule runs.
+type ScheduleType string
+
+const (
+	ScheduleTypeExtract      ScheduleType = "Extract"
+	ScheduleTypeSubscription ScheduleType = "Subscription"
+	ScheduleTypeFlow         ScheduleType = "Flow"
+)
+
+// ScheduleFrequency represents how often a schedule runs.
+type ScheduleFrequency string
+
+const (
+	ScheduleFrequencyHourly  ScheduleFrequency = "Hourly"
+	ScheduleFrequencyDaily   ScheduleFrequency = "Daily"
+	ScheduleFrequencyWeekly  ScheduleFrequency = "Weekly"
+	ScheduleFrequencyMonthly ScheduleFrequency = "Monthly"
+)
+
+// ScheduleExecutionOrder represents whether the tasks of a schedule run one
+// at a time or all at once.
+type ScheduleExecutionOrder string
+
+const (
+	ScheduleExecutionOrderParallel ScheduleExecutionOrder = "Parallel"
+	ScheduleExecutionOrderSerial   ScheduleExecutionOrder = "Serial"
+)
+
+// Schedule states.
+const (
+	ScheduleStateActive    = "Active"
+	ScheduleStateSuspended = "Suspended"
+)
+
+// FrequencyDetails describes when a schedule runs. Start and End are times of
+// day, i.e; "14:00:00"; End is only used by hourly schedules.
+type FrequencyDetails struct {
+	Start     string             `json:"start,omitempty"`
+	End       string             `json:"end,omitempty"`
+	Intervals *ScheduleIntervals `json:"intervals,omitempty"`
+}
+
+// TaskSchedule is a schedule embedded in a task, as Tableau Cloud uses in
+// place of server schedules. Build one with HourlySchedule, DailySchedule,
+// WeeklySchedule or MonthlySchedule.
+type TaskSchedule struct {
+	Frequency        ScheduleFrequency `json:"frequency"`
+	FrequencyDetails *FrequencyDetails `json:"frequencyDetails"`
+}
+
+// HourlySchedule returns a TaskSchedule running every hours hours between the
+// start and end times of day, i.e; "08:00:00" and "18:00:00", on the given
+// week days, or every day if none are given.
+func HourlySchedule(hours int, start, end string, weekDays ...string) *TaskSchedule {
+	intervals := []*ScheduleInterval{{Hours: strconv.Itoa(hours)}}
+	return newTaskSchedule(ScheduleFrequencyHourly, start, end, append(intervals, weekDayIntervals(weekDays)...))
+}
+
+// DailySchedule returns a TaskSchedule running once at the start time of day
+// on the given week days, or every day if none are given.
+func DailySchedule(start string, weekDays ...string) *TaskSchedule {
+	intervals := []*ScheduleInterval{{Hours: "24"}}
+	return newTaskSchedule(ScheduleFrequencyDaily, start, "", append(intervals, weekDayIntervals(weekDays)...))
+}
+
+// WeeklySchedule returns a TaskSchedule running at the start time of day on
+// the given week days, i.e; "Monday".
+func WeeklySchedule(start string, weekDays ...string) *TaskSchedule {
+	return newTaskSchedule(ScheduleFrequencyWeekly, start, "", weekDayIntervals(weekDays))
+}
+
+// MonthlySchedule returns a TaskSchedule running at the start time of day on
+// the given days of the month, i.e; "1" or "LastDay".
+func MonthlySchedule(start string, monthDays ...string) *TaskSchedule {
+	intervals := make([]*ScheduleInterval, len(monthDays))
+	for i, day := range monthDays {
+		intervals[i] = &ScheduleInterval{MonthDay: day}
+	}
+	return newTaskSchedule(ScheduleFrequencyMonthly, start, "", intervals)
+}
+
+func newTaskSchedule(frequency ScheduleFrequency, start, end string, intervals []*ScheduleInterval) *TaskSchedule {
+	return &TaskSchedule{
+		Frequency: frequency,
+		FrequencyDetails: &FrequencyDetails{
+			Start:     start,
+			End:       end,
+			Intervals: &ScheduleIntervals{Interval: intervals},
+		},
+	}
+}
+
+func weekDayIntervals(weekDays []string) []*ScheduleInterval {
+	intervals := make([]*ScheduleInterval, len(weekDays))
+	for i, day := range weekDays {
+		intervals[i] = &ScheduleInterval{WeekDay: day}
+	}
+	return intervals
+}
+
+// Schedule represents a Tableau Server schedule, i.e; the schedule an extract
+// refresh task runs on.
+type Schedule struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	State            string                 `json:"state"`
+	Priority         int                    `json:"priority,string"`
+	Type             ScheduleType           `json:"type"`
+	Frequency        ScheduleFrequency      `json:"frequency"`
+	FrequencyDetails *FrequencyDetails      `json:"frequencyDetails"`
+	ExecutionOrder   ScheduleExecutionOrder `json:"executionOrder"`
+	CreatedAt        time.Time              `json:"createdAt"`
+	UpdatedAt        time.Time              `json:"updatedAt"`
+	NextRunAt        time.Time              `json:"nextRunAt"`
+}
+
+// CreateScheduleRequest encapsulates the request for creating a new server
+// schedule.
+type CreateScheduleRequest struct {
+	Name string `json:"name"`
+	// Priority ranges from 1, the highest, to 100.
+	Priority         int                    `json:"priority,string,omitempty"`
+	Type             ScheduleType           `json:"type"`
+	Frequency        ScheduleFrequency      `json:"frequency"`
+	FrequencyDetails *FrequencyDetails      `json:"frequencyDetails"`
+	ExecutionOrder   ScheduleExecutionOrder `json:"executionOrder,omitempty"`
+}
+
+// UpdateScheduleRequest encapsulates the request for updating a server
+// schedule. Empty fields are left unchanged on the server.
+type UpdateScheduleRequest struct {
+	ID               string                 `json:"-"`
+	Name             string                 `json:"name,omitempty"`
+	State            string                 `json:"state,omitempty"`
+	Priority         int                    `json:"priority,string,omitempty"`
+	Frequency        ScheduleFrequency      `json:"frequency,omitempty"`
+	FrequencyDetails *FrequencyDetails      `json:"frequencyDetails,omitempty"`
+	ExecutionOrder   ScheduleExecutionOrder `json:"executionOrder,omitempty"`
+}
+
+type scheduleResponse struct {
+	Schedule *Schedule `json:"schedule"`
+}
+
+type querySchedulesResponse struct {
+	Pagination Pagination
+	Schedules  struct {
+		Schedule []*Schedule `json:"schedule"`
+	} `json:"schedules"`
+}
+
+// schedulesService manages the server schedules of Tableau Server. Tableau
+// Cloud has no server schedules.
+type schedulesService struct {
+	client *Client
+}
+
+func (ss *schedulesService) Create(ctx context.Context, createReq *CreateScheduleRequest) (*Schedule, error) {
+	request := struct {
+		Schedule *CreateScheduleRequest `json:"schedule"`
+	}{
+		Schedule: createReq,
+	}
+
+	req, err := ss.client.newRequest(http.MethodPost, "schedules", request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for create schedule")
+	}
+
+	resp := &scheduleResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Schedule, nil
+}
+
+func (ss *schedulesService) Query(ctx context.Context, opts ...QueryOption) ([]*Schedule, *Pagination, error) {
+	path, err := applyQueryOptions("schedules", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := ss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query schedules")
+	}
+
+	resp := &querySchedulesResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Schedules.Schedule, &resp.Pagination, nil
+}
+
+func (ss *schedulesService) Get(ctx context.Context, id string) (*Schedule, error) {
+	err := ss.client.requireAPIVersion("3.8", "get schedule")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ss.client.newRequest(http.MethodGet, "schedules/"+id, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get schedule")
+	}
+
+	resp := &scheduleResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Schedule, nil
+}
+
+func (ss *schedulesService) Update(ctx context.Context, updateReq *UpdateScheduleRequest) (*Schedule, error) {
+	request := struct {
+		Schedule *UpdateScheduleRequest `json:"schedule"`
+	}{
+		Schedule: updateReq,
+	}
+
+	req, err := ss.client.newRequest(http.MethodPut, "schedules/"+updateReq.ID, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update schedule")
+	}
+
+	resp := &scheduleResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Schedule, nil
+}
+
+func (ss *schedulesService) Delete(ctx context.Context, id string) error {
+	req, err := ss.client.newRequest(http.MethodDelete, "schedules/"+id, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete schedule")
+	}
+	err = ss.client.do(ctx, req, nil)
+	return err
+}
+
+// AddWorkbook adds an extract refresh of the workbook to the schedule and
+// returns the created task, whose ID identifies it in ExtractRefreshTasks.
+func (ss *schedulesService) AddWorkbook(ctx context.Context, scheduleID, workbookID string) (*ExtractRefreshTask, error) {
+	path := fmt.Sprintf("sites/%s/schedules/%s/workbooks", ss.client.SiteID, scheduleID)
+	return ss.addContent(ctx, path, map[string]resourceID{"workbook": {ID: workbookID}})
+}
+
+// AddDataSource adds an extract refresh of the data source to the schedule
+// and returns the created task, whose ID identifies it in
+// ExtractRefreshTasks.
+func (ss *schedulesService) AddDataSource(ctx context.Context, scheduleID, dataSourceID string) (*ExtractRefreshTask, error) {
+	path := fmt.Sprintf("sites/%s/schedules/%s/datasources", ss.client.SiteID, scheduleID)
+	return ss.addContent(ctx, path, map[string]resourceID{"datasource": {ID: dataSourceID}})
+}
+
+func (ss *schedulesService) addContent(ctx context.Context, path string, content map[string]resourceID) (*ExtractRefreshTask, error) {
+	request := struct {
+		Task struct {
+			ExtractRefresh map[string]resourceID `json:"extractRefresh"`
+		} `json:"task"`
+	}{}
+	request.Task.ExtractRefresh = content
+
+	req, err := ss.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for add content to schedule")
+	}
+
+	resp := &extractRefreshTaskResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Task.ExtractRefresh, nil
+}
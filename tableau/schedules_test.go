@@ -0,0 +1,148 @@
+package tableau
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestScheduleIntervalsJSON(t *testing.T) {
+	tests := []struct {
+		desc      string
+		intervals *ScheduleIntervals
+		want      string
+	}{
+		{
+			desc:      "hourly",
+			intervals: &ScheduleIntervals{Interval: []*ScheduleInterval{{Hours: "4"}}},
+			want:      `{"interval":[{"hours":"4"}]}`,
+		},
+		{
+			desc: "weekly",
+			intervals: &ScheduleIntervals{Interval: []*ScheduleInterval{
+				{WeekDay: "Monday"},
+				{WeekDay: "Thursday"},
+			}},
+			want: `{"interval":[{"weekDay":"Monday"},{"weekDay":"Thursday"}]}`,
+		},
+		{
+			desc:      "monthly",
+			intervals: &ScheduleIntervals{Interval: []*ScheduleInterval{{MonthDay: "LastDay"}}},
+			want:      `{"interval":[{"monthDay":"LastDay"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			b, err := json.Marshal(tt.intervals)
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(b), qt.Equals, tt.want)
+		})
+	}
+}
+
+func TestSchedulesCreate(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/schedules", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPost)
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+			"schedule": map[string]interface{}{
+				"name":           "Nightly",
+				"priority":       "50",
+				"type":           "Extract",
+				"frequency":      "Daily",
+				"executionOrder": "Serial",
+				"frequencyDetails": map[string]interface{}{
+					"start": "02:00:00",
+					"intervals": map[string]interface{}{
+						"interval": []interface{}{map[string]interface{}{"hours": "24"}},
+					},
+				},
+			},
+		})
+		_, _ = w.Write([]byte(`{"schedule": {"id": "schedule-id", "name": "Nightly", "priority": "50", "type": "Extract", "frequency": "Daily"}}`))
+	})
+
+	schedule, err := client.Schedules.Create(context.Background(), &CreateScheduleRequest{
+		Name:      "Nightly",
+		Priority:  50,
+		Type:      ScheduleTypeExtract,
+		Frequency: ScheduleFrequencyDaily,
+		FrequencyDetails: &FrequencyDetails{
+			Start:     "02:00:00",
+			Intervals: &ScheduleIntervals{Interval: []*ScheduleInterval{{Hours: "24"}}},
+		},
+		ExecutionOrder: ScheduleExecutionOrderSerial,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(schedule.ID, qt.Equals, "schedule-id")
+	c.Assert(schedule.Priority, qt.Equals, 50)
+	c.Assert(schedule.Type, qt.Equals, ScheduleTypeExtract)
+}
+
+func TestSchedulesAddWorkbook(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/schedules/schedule-id/workbooks", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPut)
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+			"task": map[string]interface{}{
+				"extractRefresh": map[string]interface{}{
+					"workbook": map[string]interface{}{"id": "workbook-id"},
+				},
+			},
+		})
+		_, _ = w.Write([]byte(`{"task": {"extractRefresh": {"id": "task-id", "workbook": {"id": "workbook-id"}}}}`))
+	})
+
+	task, err := client.Schedules.AddWorkbook(context.Background(), "schedule-id", "workbook-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(task.ID, qt.Equals, "task-id")
+	c.Assert(task.Workbook.ID, qt.Equals, "workbook-id")
+}
+
+func TestTaskScheduleBuilders(t *testing.T) {
+	tests := []struct {
+		desc     string
+		schedule *TaskSchedule
+		want     string
+	}{
+		{
+			desc:     "hourly on week days",
+			schedule: HourlySchedule(2, "08:00:00", "18:00:00", "Monday", "Friday"),
+			want:     `{"frequency":"Hourly","frequencyDetails":{"start":"08:00:00","end":"18:00:00","intervals":{"interval":[{"hours":"2"},{"weekDay":"Monday"},{"weekDay":"Friday"}]}}}`,
+		},
+		{
+			desc:     "daily",
+			schedule: DailySchedule("02:00:00"),
+			want:     `{"frequency":"Daily","frequencyDetails":{"start":"02:00:00","intervals":{"interval":[{"hours":"24"}]}}}`,
+		},
+		{
+			desc:     "weekly",
+			schedule: WeeklySchedule("06:30:00", "Monday", "Thursday"),
+			want:     `{"frequency":"Weekly","frequencyDetails":{"start":"06:30:00","intervals":{"interval":[{"weekDay":"Monday"},{"weekDay":"Thursday"}]}}}`,
+		},
+		{
+			desc:     "monthly",
+			schedule: MonthlySchedule("02:00:00", "1", "LastDay"),
+			want:     `{"frequency":"Monthly","frequencyDetails":{"start":"02:00:00","intervals":{"interval":[{"monthDay":"1"},{"monthDay":"LastDay"}]}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			b, err := json.Marshal(tt.schedule)
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(b), qt.Equals, tt.want)
+		})
+	}
+}
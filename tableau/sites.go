@@ -0,0 +1,259 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+// Site represents a Tableau site
+type Site struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	ContentUrl             string `json:"contentUrl"`
+	State                  string `json:"state"`
+	AdminMode              string `json:"adminMode"`
+	UserQuota              int    `json:"userQuota,string"`
+	StorageQuota           int    `json:"storageQuota,string"`
+	DisableSubscriptions   bool   `json:"disableSubscriptions"`
+	SubscribeOthersEnabled bool   `json:"subscribeOthersEnabled"`
+	RevisionHistoryEnabled bool   `json:"revisionHistoryEnabled"`
+	RevisionLimit          int    `json:"revisionLimit,string"`
+	FlowsEnabled           bool   `json:"flowsEnabled"`
+	CatalogingEnabled      bool   `json:"catalogingEnabled"`
+	CommentingEnabled      bool   `json:"commentingEnabled"`
+	GuestAccessEnabled     bool   `json:"guestAccessEnabled"`
+	CacheWarmupEnabled     bool   `json:"cacheWarmupEnabled"`
+	DataAlertsEnabled      bool   `json:"dataAlertsEnabled"`
+	ExtractEncryptionMode  string `json:"extractEncryptionMode"`
+}
+
+// Site admin modes.
+const (
+	SiteAdminModeContentAndUsers = "ContentAndUsers"
+	SiteAdminModeContentOnly     = "ContentOnly"
+)
+
+// CreateSiteRequest encapsulates the request for creating a new site.
+type CreateSiteRequest struct {
+	Name         string `json:"name"`
+	ContentUrl   string `json:"contentUrl"`
+	AdminMode    string `json:"adminMode,omitempty"`
+	UserQuota    int    `json:"userQuota,string,omitempty"`
+	StorageQuota int    `json:"storageQuota,string,omitempty"`
+	FlowsEnabled *bool  `json:"flowsEnabled,omitempty"`
+}
+
+// UpdateSiteRequest encapsulates the request for updating a site. Empty
+// fields are left unchanged on the server.
+type UpdateSiteRequest struct {
+	ID                     string `json:"-"`
+	Name                   string `json:"name,omitempty"`
+	ContentUrl             string `json:"contentUrl,omitempty"`
+	State                  string `json:"state,omitempty"`
+	AdminMode              string `json:"adminMode,omitempty"`
+	UserQuota              int    `json:"userQuota,string,omitempty"`
+	StorageQuota           int    `json:"storageQuota,string,omitempty"`
+	DisableSubscriptions   *bool  `json:"disableSubscriptions,omitempty"`
+	SubscribeOthersEnabled *bool  `json:"subscribeOthersEnabled,omitempty"`
+	RevisionHistoryEnabled *bool  `json:"revisionHistoryEnabled,omitempty"`
+	RevisionLimit          int    `json:"revisionLimit,string,omitempty"`
+	FlowsEnabled           *bool  `json:"flowsEnabled,omitempty"`
+	CatalogingEnabled      *bool  `json:"catalogingEnabled,omitempty"`
+	CommentingEnabled      *bool  `json:"commentingEnabled,omitempty"`
+}
+
+// RecentContent is a piece of content the signed in user viewed recently.
+// Exactly one of the fields is set, depending on the type of the content.
+type RecentContent struct {
+	Workbook   *Workbook   `json:"workbook"`
+	View       *View       `json:"view"`
+	DataSource *DataSource `json:"datasource"`
+}
+
+type recentContentResponse struct {
+	Recents struct {
+		Recent []*RecentContent `json:"recent"`
+	} `json:"recents"`
+}
+
+type siteResponse struct {
+	Site *Site `json:"site"`
+}
+
+type querySitesResponse struct {
+	Pagination Pagination
+	Sites      struct {
+		Site []*Site `json:"site"`
+	} `json:"sites"`
+}
+
+type sitesService struct {
+	client *Client
+}
+
+func (ss *sitesService) Query(ctx context.Context, opts ...QueryOption) ([]*Site, error) {
+	path, err := applyQueryOptions("sites", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query sites")
+	}
+
+	resp := &querySitesResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Sites.Site, nil
+}
+
+func (ss *sitesService) Get(ctx context.Context, id string) (*Site, error) {
+	return ss.get(ctx, "sites/"+url.PathEscape(id))
+}
+
+// Current returns the site the client is signed in to, i.e; to read its
+// quotas, state and settings.
+func (ss *sitesService) Current(ctx context.Context) (*Site, error) {
+	return ss.Get(ctx, ss.client.SiteID)
+}
+
+// GetByName returns the site with the given name.
+func (ss *sitesService) GetByName(ctx context.Context, name string) (*Site, error) {
+	return ss.get(ctx, fmt.Sprintf("sites/%s?key=name", url.PathEscape(name)))
+}
+
+// GetByContentURL returns the site identified by its content URL.
+func (ss *sitesService) GetByContentURL(ctx context.Context, contentUrl string) (*Site, error) {
+	return ss.get(ctx, fmt.Sprintf("sites/%s?key=contentUrl", url.PathEscape(contentUrl)))
+}
+
+// Create creates a site. Only server administrators can create sites.
+func (ss *sitesService) Create(ctx context.Context, createReq *CreateSiteRequest) (*Site, error) {
+	request := struct {
+		Site *CreateSiteRequest `json:"site"`
+	}{
+		Site: createReq,
+	}
+
+	req, err := ss.client.newRequest(http.MethodPost, "sites", request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for create site")
+	}
+
+	resp := &siteResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Site, nil
+}
+
+func (ss *sitesService) Update(ctx context.Context, updateReq *UpdateSiteRequest) (*Site, error) {
+	path := "sites/" + url.PathEscape(updateReq.ID)
+
+	request := struct {
+		Site *UpdateSiteRequest `json:"site"`
+	}{
+		Site: updateReq,
+	}
+
+	req, err := ss.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update site")
+	}
+
+	resp := &siteResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Site, nil
+}
+
+// Delete deletes the site with the given id along with all of its content.
+func (ss *sitesService) Delete(ctx context.Context, id string) error {
+	req, err := ss.client.newRequest(http.MethodDelete, "sites/"+url.PathEscape(id), nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete site")
+	}
+	err = ss.client.do(ctx, req, nil)
+	return err
+}
+
+func (ss *sitesService) get(ctx context.Context, path string) (*Site, error) {
+	req, err := ss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get site")
+	}
+
+	resp := &siteResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Site, nil
+}
+
+// RecentlyViewed returns the content the signed in user viewed most recently on
+// the current site, most recent first.
+func (ss *sitesService) RecentlyViewed(ctx context.Context) ([]*RecentContent, error) {
+	err := ss.client.requireAPIVersion("3.5", "recently viewed content")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/content/recent", ss.client.SiteID)
+	req, err := ss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for recently viewed content")
+	}
+
+	resp := &recentContentResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Recents.Recent, nil
+}
+
+// EncryptExtracts encrypts all the extracts of the site with the given id.
+// The server encrypts them in the background.
+func (ss *sitesService) EncryptExtracts(ctx context.Context, id string) error {
+	return ss.extractEncryption(ctx, id, "encrypt")
+}
+
+// DecryptExtracts decrypts all the extracts of the site with the given id.
+// The server decrypts them in the background.
+func (ss *sitesService) DecryptExtracts(ctx context.Context, id string) error {
+	return ss.extractEncryption(ctx, id, "decrypt")
+}
+
+// ReencryptExtracts re-encrypts all the extracts of the site with the given id
+// with new keys, i.e; after rotating the server's master key.
+func (ss *sitesService) ReencryptExtracts(ctx context.Context, id string) error {
+	return ss.extractEncryption(ctx, id, "reencrypt")
+}
+
+func (ss *sitesService) extractEncryption(ctx context.Context, id, operation string) error {
+	err := ss.client.requireAPIVersion("3.5", "extract encryption")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/%s-extracts", url.PathEscape(id), operation)
+	req, err := ss.client.newRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error creating request for %s extracts", operation)
+	}
+	err = ss.client.do(ctx, req, nil)
+	return err
+}
@@ -0,0 +1,225 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+)
+
+// Subscription content types.
+const (
+	SubscriptionContentView     = "View"
+	SubscriptionContentWorkbook = "Workbook"
+)
+
+// Subscription represents an email subscription of a user to a view or a
+// workbook.
+type Subscription struct {
+	ID          string              `json:"id"`
+	Subject     string              `json:"subject"`
+	Message     string              `json:"message"`
+	AttachImage bool                `json:"attachImage"`
+	AttachPDF   bool                `json:"attachPdf"`
+	Suspended   bool                `json:"suspended"`
+	Content     SubscriptionContent `json:"content"`
+	// Schedule is the server schedule of the subscription on Tableau Server,
+	// or its embedded schedule on Tableau Cloud.
+	Schedule *Schedule `json:"schedule"`
+	User     struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
+// SubscriptionContent identifies the view or workbook a subscription sends.
+type SubscriptionContent struct {
+	ID string `json:"id"`
+	// Type is one of the SubscriptionContent constants.
+	Type            string `json:"type"`
+	SendIfViewEmpty bool   `json:"sendIfViewEmpty"`
+}
+
+// CreateSubscriptionRequest encapsulates the request for creating a new
+// subscription. ScheduleID is used on Tableau Server and Schedule on Tableau
+// Cloud; only one of them may be set.
+type CreateSubscriptionRequest struct {
+	Subject         string
+	Message         string
+	ContentID       string
+	ContentType     string
+	SendIfViewEmpty bool
+	UserID          string
+	AttachImage     *bool
+	AttachPDF       *bool
+	ScheduleID      string
+	Schedule        *TaskSchedule
+}
+
+// UpdateSubscriptionRequest encapsulates the request for updating a
+// subscription. Empty fields are left unchanged on the server.
+type UpdateSubscriptionRequest struct {
+	ID          string
+	Subject     string
+	Message     string
+	UserID      string
+	AttachImage *bool
+	AttachPDF   *bool
+	Suspended   *bool
+	ScheduleID  string
+	Schedule    *TaskSchedule
+}
+
+type subscriptionPayload struct {
+	Subject     string               `json:"subject,omitempty"`
+	Message     string               `json:"message,omitempty"`
+	AttachImage *bool                `json:"attachImage,omitempty"`
+	AttachPDF   *bool                `json:"attachPdf,omitempty"`
+	Suspended   *bool                `json:"suspended,omitempty"`
+	Content     *SubscriptionContent `json:"content,omitempty"`
+	Schedule    *resourceID          `json:"schedule,omitempty"`
+	User        *resourceID          `json:"user,omitempty"`
+}
+
+// subscriptionRequest is the body of create and update requests. Schedule is
+// the embedded schedule used by Tableau Cloud.
+type subscriptionRequest struct {
+	Subscription *subscriptionPayload `json:"subscription"`
+	Schedule     *TaskSchedule        `json:"schedule,omitempty"`
+}
+
+type subscriptionResponse struct {
+	Subscription *Subscription `json:"subscription"`
+}
+
+type querySubscriptionsResponse struct {
+	Pagination    Pagination
+	Subscriptions struct {
+		Subscription []*Subscription `json:"subscription"`
+	} `json:"subscriptions"`
+}
+
+type subscriptionsService struct {
+	client *Client
+}
+
+func (ss *subscriptionsService) Create(ctx context.Context, createReq *CreateSubscriptionRequest) (*Subscription, error) {
+	if createReq.ScheduleID != "" && createReq.Schedule != nil {
+		return nil, errors.New("subscription must have either a schedule id or a schedule, not both")
+	}
+
+	path := fmt.Sprintf("sites/%s/subscriptions", ss.client.SiteID)
+
+	payload := &subscriptionPayload{
+		Subject:     createReq.Subject,
+		Message:     createReq.Message,
+		AttachImage: createReq.AttachImage,
+		AttachPDF:   createReq.AttachPDF,
+		User:        &resourceID{ID: createReq.UserID},
+		Content: &SubscriptionContent{
+			ID:              createReq.ContentID,
+			Type:            createReq.ContentType,
+			SendIfViewEmpty: createReq.SendIfViewEmpty,
+		},
+	}
+	if createReq.ScheduleID != "" {
+		payload.Schedule = &resourceID{ID: createReq.ScheduleID}
+	}
+
+	req, err := ss.client.newRequest(http.MethodPost, path, subscriptionRequest{
+		Subscription: payload,
+		Schedule:     createReq.Schedule,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for create subscription")
+	}
+
+	resp := &subscriptionResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Subscription, nil
+}
+
+func (ss *subscriptionsService) Query(ctx context.Context, opts ...QueryOption) ([]*Subscription, *Pagination, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/subscriptions", ss.client.SiteID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := ss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query subscriptions")
+	}
+
+	resp := &querySubscriptionsResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Subscriptions.Subscription, &resp.Pagination, nil
+}
+
+func (ss *subscriptionsService) Get(ctx context.Context, id string) (*Subscription, error) {
+	path := fmt.Sprintf("sites/%s/subscriptions/%s", ss.client.SiteID, id)
+	req, err := ss.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get subscription")
+	}
+
+	resp := &subscriptionResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Subscription, nil
+}
+
+func (ss *subscriptionsService) Update(ctx context.Context, updateReq *UpdateSubscriptionRequest) (*Subscription, error) {
+	if updateReq.ScheduleID != "" && updateReq.Schedule != nil {
+		return nil, errors.New("subscription must have either a schedule id or a schedule, not both")
+	}
+
+	path := fmt.Sprintf("sites/%s/subscriptions/%s", ss.client.SiteID, updateReq.ID)
+
+	payload := &subscriptionPayload{
+		Subject:     updateReq.Subject,
+		Message:     updateReq.Message,
+		AttachImage: updateReq.AttachImage,
+		AttachPDF:   updateReq.AttachPDF,
+		Suspended:   updateReq.Suspended,
+	}
+	if updateReq.UserID != "" {
+		payload.User = &resourceID{ID: updateReq.UserID}
+	}
+	if updateReq.ScheduleID != "" {
+		payload.Schedule = &resourceID{ID: updateReq.ScheduleID}
+	}
+
+	req, err := ss.client.newRequest(http.MethodPut, path, subscriptionRequest{
+		Subscription: payload,
+		Schedule:     updateReq.Schedule,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update subscription")
+	}
+
+	resp := &subscriptionResponse{}
+	err = ss.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Subscription, nil
+}
+
+func (ss *subscriptionsService) Delete(ctx context.Context, id string) error {
+	path := fmt.Sprintf("sites/%s/subscriptions/%s", ss.client.SiteID, id)
+	req, err := ss.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete subscription")
+	}
+	err = ss.client.do(ctx, req, nil)
+	return err
+}
@@ -0,0 +1,126 @@
+package tableau
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSubscriptionsCreate(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPost)
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+			"subscription": map[string]interface{}{
+				"subject":   "Weekly sales",
+				"attachPdf": true,
+				"content": map[string]interface{}{
+					"id":              "view-id",
+					"type":            "View",
+					"sendIfViewEmpty": false,
+				},
+				"user": map[string]interface{}{"id": "user-id"},
+			},
+			"schedule": map[string]interface{}{
+				"frequency": "Weekly",
+				"frequencyDetails": map[string]interface{}{
+					"start": "08:00:00",
+					"intervals": map[string]interface{}{
+						"interval": []interface{}{
+							map[string]interface{}{"weekDay": "Monday"},
+						},
+					},
+				},
+			},
+		})
+		_, _ = w.Write([]byte(`{"subscription": {"id": "subscription-id", "subject": "Weekly sales", "content": {"id": "view-id", "type": "View"}}}`))
+	})
+
+	attachPDF := true
+	sub, err := client.Subscriptions.Create(context.Background(), &CreateSubscriptionRequest{
+		Subject:     "Weekly sales",
+		ContentID:   "view-id",
+		ContentType: SubscriptionContentView,
+		UserID:      "user-id",
+		AttachPDF:   &attachPDF,
+		Schedule:    WeeklySchedule("08:00:00", "Monday"),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(sub.ID, qt.Equals, "subscription-id")
+	c.Assert(sub.Content.Type, qt.Equals, SubscriptionContentView)
+}
+
+func TestSubscriptionsUpdate(t *testing.T) {
+	suspended := true
+	resumed := false
+
+	tests := []struct {
+		desc     string
+		req      *UpdateSubscriptionRequest
+		wantBody map[string]interface{}
+	}{
+		{
+			desc: "suspends",
+			req:  &UpdateSubscriptionRequest{ID: "subscription-id", Suspended: &suspended},
+			wantBody: map[string]interface{}{
+				"subscription": map[string]interface{}{"suspended": true},
+			},
+		},
+		{
+			desc: "resumes on a server schedule",
+			req:  &UpdateSubscriptionRequest{ID: "subscription-id", Suspended: &resumed, ScheduleID: "schedule-id"},
+			wantBody: map[string]interface{}{
+				"subscription": map[string]interface{}{
+					"suspended": false,
+					"schedule":  map[string]interface{}{"id": "schedule-id"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := qt.New(t)
+			client := newTestClient(t, "/api/3.4/sites/site-id/subscriptions/subscription-id", func(w http.ResponseWriter, r *http.Request) {
+				c.Check(r.Method, qt.Equals, http.MethodPut)
+				body, err := ioutil.ReadAll(r.Body)
+				c.Check(err, qt.IsNil)
+				c.Check(string(body), qt.JSONEquals, tt.wantBody)
+				_, _ = w.Write([]byte(`{"subscription": {"id": "subscription-id", "suspended": true}}`))
+			})
+
+			sub, err := client.Subscriptions.Update(context.Background(), tt.req)
+			c.Assert(err, qt.IsNil)
+			c.Assert(sub.ID, qt.Equals, "subscription-id")
+		})
+	}
+}
+
+func TestSubscriptionsScheduleConflict(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		c.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := client.Subscriptions.Create(context.Background(), &CreateSubscriptionRequest{
+		Subject:     "Weekly sales",
+		ContentID:   "view-id",
+		ContentType: SubscriptionContentView,
+		UserID:      "user-id",
+		ScheduleID:  "schedule-id",
+		Schedule:    WeeklySchedule("08:00:00", "Monday"),
+	})
+	c.Assert(err, qt.ErrorMatches, "subscription must have either a schedule id or a schedule, not both")
+
+	_, err = client.Subscriptions.Update(context.Background(), &UpdateSubscriptionRequest{
+		ID:         "subscription-id",
+		ScheduleID: "schedule-id",
+		Schedule:   WeeklySchedule("08:00:00", "Monday"),
+	})
+	c.Assert(err, qt.ErrorMatches, "subscription must have either a schedule id or a schedule, not both")
+}
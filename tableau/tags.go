@@ -0,0 +1,53 @@
+package tableau
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+// Tag represents a tag attached to a piece of content.
+type Tag struct {
+	Label string `json:"label"`
+}
+
+// Tags is the wire representation of a list of tags.
+type Tags struct {
+	Tag []*Tag `json:"tag"`
+}
+
+type tagsResponse struct {
+	Tags Tags `json:"tags"`
+}
+
+// addTags adds tags to the content whose tags live at path.
+func (c *Client) addTags(ctx context.Context, path string, tags []string) ([]*Tag, error) {
+	request := tagsResponse{}
+	for _, label := range tags {
+		request.Tags.Tag = append(request.Tags.Tag, &Tag{Label: label})
+	}
+
+	req, err := c.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for add tags")
+	}
+
+	resp := &tagsResponse{}
+	err = c.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Tags.Tag, nil
+}
+
+// deleteTag removes tag from the content whose tags live at path.
+func (c *Client) deleteTag(ctx context.Context, path, tag string) error {
+	req, err := c.newRequest(http.MethodDelete, path+"/"+url.PathEscape(tag), nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete tag")
+	}
+	err = c.do(ctx, req, nil)
+	return err
+}
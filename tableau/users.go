@@ -0,0 +1,225 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SiteRole represents the role of a user on a site.
+type SiteRole string
+
+const (
+	SiteRoleCreator                   SiteRole = "Creator"
+	SiteRoleExplorer                  SiteRole = "Explorer"
+	SiteRoleExplorerCanPublish        SiteRole = "ExplorerCanPublish"
+	SiteRoleReadOnly                  SiteRole = "ReadOnly"
+	SiteRoleServerAdministrator       SiteRole = "ServerAdministrator"
+	SiteRoleSiteAdministratorCreator  SiteRole = "SiteAdministratorCreator"
+	SiteRoleSiteAdministratorExplorer SiteRole = "SiteAdministratorExplorer"
+	SiteRoleUnlicensed                SiteRole = "Unlicensed"
+	SiteRoleViewer                    SiteRole = "Viewer"
+)
+
+// User represents a Tableau user
+type User struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name"`
+	FullName           string    `json:"fullName"`
+	Email              string    `json:"email"`
+	SiteRole           SiteRole  `json:"siteRole"`
+	AuthSetting        string    `json:"authSetting"`
+	ExternalAuthUserID string    `json:"externalAuthUserId"`
+	LastLogin          time.Time `json:"lastLogin"`
+}
+
+// AddUserRequest encapsulates the request for adding a user to the site.
+type AddUserRequest struct {
+	Name        string   `json:"name"`
+	SiteRole    SiteRole `json:"siteRole"`
+	AuthSetting string   `json:"authSetting,omitempty"`
+}
+
+// UpdateUserRequest encapsulates the request for updating a user. Empty
+// fields are left unchanged on the server.
+type UpdateUserRequest struct {
+	ID          string   `json:"-"`
+	FullName    string   `json:"fullName,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Password    string   `json:"password,omitempty"`
+	SiteRole    SiteRole `json:"siteRole,omitempty"`
+	AuthSetting string   `json:"authSetting,omitempty"`
+}
+
+type userResponse struct {
+	User *User `json:"user"`
+}
+
+type usersResponse struct {
+	Pagination Pagination
+	Users      struct {
+		User []*User `json:"user"`
+	} `json:"users"`
+}
+
+type usersService struct {
+	client *Client
+}
+
+func (us *usersService) Query(ctx context.Context, opts ...QueryOption) ([]*User, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/users", us.client.SiteID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := us.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query users")
+	}
+
+	resp := &usersResponse{}
+	err = us.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Users.User, nil
+}
+
+func (us *usersService) Get(ctx context.Context, id string) (*User, error) {
+	path := fmt.Sprintf("sites/%s/users/%s", us.client.SiteID, id)
+	req, err := us.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get user")
+	}
+
+	resp := &userResponse{}
+	err = us.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.User, nil
+}
+
+// Groups returns the groups the user with the given id is a member of.
+func (us *usersService) Groups(ctx context.Context, userID string, opts ...QueryOption) ([]*Group, *Pagination, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/users/%s/groups", us.client.SiteID, userID), opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := us.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating request for query user groups")
+	}
+
+	resp := &queryGroupsResponse{}
+	err = us.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Groups.Group, &resp.Pagination, nil
+}
+
+// Add adds a user to the site, creating the user on the server if needed.
+func (us *usersService) Add(ctx context.Context, addReq *AddUserRequest) (*User, error) {
+	path := fmt.Sprintf("sites/%s/users", us.client.SiteID)
+
+	request := struct {
+		User *AddUserRequest `json:"user"`
+	}{
+		User: addReq,
+	}
+
+	req, err := us.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for add user")
+	}
+
+	resp := &userResponse{}
+	err = us.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+func (us *usersService) Update(ctx context.Context, updateReq *UpdateUserRequest) (*User, error) {
+	path := fmt.Sprintf("sites/%s/users/%s", us.client.SiteID, updateReq.ID)
+
+	request := struct {
+		User *UpdateUserRequest `json:"user"`
+	}{
+		User: updateReq,
+	}
+
+	req, err := us.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update user")
+	}
+
+	resp := &userResponse{}
+	err = us.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.User, nil
+}
+
+// Remove removes a user from the site. The user must not own any content.
+func (us *usersService) Remove(ctx context.Context, id string) error {
+	path := fmt.Sprintf("sites/%s/users/%s", us.client.SiteID, id)
+	req, err := us.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for remove user")
+	}
+	err = us.client.do(ctx, req, nil)
+	return err
+}
+
+// Import adds the users listed in the CSV read from r to the site. The import
+// runs asynchronously, so the job processing it is returned. authSetting, if
+// not empty, is applied to all imported users.
+func (us *usersService) Import(ctx context.Context, r io.Reader, authSetting string) (*Job, error) {
+	err := us.client.requireAPIVersion("3.15", "user CSV import")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/users/import", us.client.SiteID)
+
+	request := struct {
+		User struct {
+			AuthSetting string `json:"authSetting,omitempty"`
+		} `json:"user"`
+	}{}
+	request.User.AuthSetting = authSetting
+
+	req, err := us.client.newMultipartRequest(http.MethodPost, path, request, "tableau_user_import", "users.csv", r)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for import users")
+	}
+
+	resp := &jobResponse{}
+	err = us.client.send(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// BulkUpdateSiteRole sets the site role of all the given users, updating
+// several users concurrently and retrying throttled or failed requests. If
+// some users couldn't be updated, a *BulkError listing them is returned.
+func (us *usersService) BulkUpdateSiteRole(ctx context.Context, userIDs []string, role SiteRole) error {
+	return runBulk(ctx, userIDs, func(ctx context.Context, id string) error {
+		_, err := us.Update(ctx, &UpdateUserRequest{ID: id, SiteRole: role})
+		return err
+	})
+}
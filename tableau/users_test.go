@@ -0,0 +1,61 @@
+package tableau
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestUsersBulkUpdateSiteRole(t *testing.T) {
+	c := qt.New(t)
+
+	delay := bulkRetryDelay
+	bulkRetryDelay = time.Millisecond
+	t.Cleanup(func() { bulkRetryDelay = delay })
+
+	var (
+		mu       sync.Mutex
+		attempts = make(map[string]int)
+	)
+	client := newTestClient(t, "/api/3.4/sites/site-id/users/", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPut)
+		id := strings.TrimPrefix(r.URL.Path, "/api/3.4/sites/site-id/users/")
+
+		var body map[string]map[string]string
+		err := json.NewDecoder(r.Body).Decode(&body)
+		c.Check(err, qt.IsNil)
+		c.Check(body["user"]["siteRole"], qt.Equals, "Unlicensed")
+
+		mu.Lock()
+		attempts[id]++
+		attempt := attempts[id]
+		mu.Unlock()
+
+		switch {
+		case id == "missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"summary": "Not Found", "detail": "user not found", "code": "404002"}}`))
+		case id == "throttled" && attempt == 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": {"summary": "Too Many Requests", "detail": "slow down", "code": "429000"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"user": {"id": "` + id + `", "siteRole": "Unlicensed"}}`))
+		}
+	})
+
+	err := client.Users.BulkUpdateSiteRole(context.Background(), []string{"a", "b", "throttled", "missing"}, SiteRoleUnlicensed)
+
+	var bulkErr *BulkError
+	c.Assert(errors.As(err, &bulkErr), qt.IsTrue)
+	c.Assert(bulkErr.Errors, qt.HasLen, 1)
+	c.Assert(bulkErr.Errors["missing"], qt.ErrorMatches, "Not Found: user not found")
+	c.Assert(attempts["missing"], qt.Equals, 1)
+	c.Assert(attempts["throttled"], qt.Equals, 2)
+}
@@ -0,0 +1,184 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// View represents a Tableau view
+type View struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ContentUrl  string `json:"contentUrl"`
+	ViewUrlName string `json:"viewUrlName"`
+	SheetType   string `json:"sheetType"`
+	Workbook    struct {
+		ID string `json:"id"`
+	}
+	Owner struct {
+		ID string `json:"id"`
+	}
+	Project struct {
+		ID string `json:"id"`
+	}
+	Tags      Tags      `json:"tags"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type viewResponse struct {
+	View *View `json:"view"`
+}
+
+type queryViewsResponse struct {
+	Views struct {
+		View []*View `json:"view"`
+	} `json:"views"`
+}
+
+// ErrViewNotFound is returned when the requested view doesn't exist.
+var ErrViewNotFound = errors.New("view not found")
+
+type viewsService struct {
+	client *Client
+}
+
+func (vs *viewsService) Query(ctx context.Context, opts ...QueryOption) ([]*View, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/views", vs.client.SiteID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := vs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query views")
+	}
+
+	resp := &queryViewsResponse{}
+	err = vs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Views.View, nil
+}
+
+func (vs *viewsService) Get(ctx context.Context, id string) (*View, error) {
+	path := fmt.Sprintf("sites/%s/views/%s", vs.client.SiteID, id)
+	req, err := vs.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get view")
+	}
+
+	resp := &viewResponse{}
+	err = vs.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.View, nil
+}
+
+// GetByPath returns the view identified by the content URL of its workbook
+// and its URL name, the parts of the view URL shown in the browser, i.e;
+// ".../views/<workbookContentURL>/<viewURLName>".
+func (vs *viewsService) GetByPath(ctx context.Context, workbookContentURL, viewURLName string) (*View, error) {
+	contentUrl := workbookContentURL + "/sheets/" + viewURLName
+	views, err := vs.Query(ctx, WithFilter(NewFilterBuilder().Eq("contentUrl", contentUrl)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range views {
+		if v.ContentUrl == contentUrl {
+			return v, nil
+		}
+	}
+	return nil, errors.Wrap(ErrViewNotFound, contentUrl)
+}
+
+// PreviewImage returns the thumbnail image of a view of the given workbook
+// along with the Content-Type reported by the server.
+func (vs *viewsService) PreviewImage(ctx context.Context, workbookID, viewID string) ([]byte, string, error) {
+	return vs.client.export(ctx, fmt.Sprintf("sites/%s/workbooks/%s/views/%s/previewImage", vs.client.SiteID, workbookID, viewID), nil)
+}
+
+// ExportImage renders the view as a PNG image. It returns the image along
+// with the Content-Type reported by the server.
+func (vs *viewsService) ExportImage(ctx context.Context, viewID string, opts ...ExportOption) ([]byte, string, error) {
+	return vs.client.export(ctx, fmt.Sprintf("sites/%s/views/%s/image", vs.client.SiteID, viewID), opts)
+}
+
+// ExportPDF renders the view as a PDF document. It returns the document along
+// with the Content-Type reported by the server.
+func (vs *viewsService) ExportPDF(ctx context.Context, viewID string, opts ...ExportOption) ([]byte, string, error) {
+	return vs.client.export(ctx, fmt.Sprintf("sites/%s/views/%s/pdf", vs.client.SiteID, viewID), opts)
+}
+
+// ExportPDFTo renders the view as a PDF document and streams it to w. It
+// returns the Content-Type reported by the server.
+func (vs *viewsService) ExportPDFTo(ctx context.Context, viewID string, w io.Writer, opts ...ExportOption) (string, error) {
+	return vs.client.exportTo(ctx, fmt.Sprintf("sites/%s/views/%s/pdf", vs.client.SiteID, viewID), w, opts)
+}
+
+// ExportData returns the summary data of the view in CSV format along with
+// the Content-Type reported by the server.
+func (vs *viewsService) ExportData(ctx context.Context, viewID string, opts ...ExportOption) ([]byte, string, error) {
+	return vs.client.export(ctx, fmt.Sprintf("sites/%s/views/%s/data", vs.client.SiteID, viewID), opts)
+}
+
+// ExportCrosstabExcel returns the crosstab of the view as an .xlsx workbook
+// along with the Content-Type reported by the server.
+func (vs *viewsService) ExportCrosstabExcel(ctx context.Context, viewID string, opts ...ExportOption) ([]byte, string, error) {
+	err := vs.client.requireAPIVersion("3.14", "crosstab excel export")
+	if err != nil {
+		return nil, "", err
+	}
+
+	return vs.client.export(ctx, fmt.Sprintf("sites/%s/views/%s/crosstab/excel", vs.client.SiteID, viewID), opts)
+}
+
+// ExportDataStream returns a reader of the summary data of the view in CSV
+// format, for data too large to hold in memory. The caller must close it.
+func (vs *viewsService) ExportDataStream(ctx context.Context, viewID string, opts ...ExportOption) (io.ReadCloser, error) {
+	return vs.client.exportStream(ctx, fmt.Sprintf("sites/%s/views/%s/data", vs.client.SiteID, viewID), opts)
+}
+
+// AddTags adds tags to the view and returns the tags that were added.
+func (vs *viewsService) AddTags(ctx context.Context, id string, tags []string) ([]*Tag, error) {
+	return vs.client.addTags(ctx, fmt.Sprintf("sites/%s/views/%s/tags", vs.client.SiteID, id), tags)
+}
+
+func (vs *viewsService) DeleteTag(ctx context.Context, id, tag string) error {
+	return vs.client.deleteTag(ctx, fmt.Sprintf("sites/%s/views/%s/tags", vs.client.SiteID, id), tag)
+}
+
+// Permissions returns the explicit permissions set on the view.
+func (vs *viewsService) Permissions(ctx context.Context, id string) (*Permissions, error) {
+	return vs.client.permissions(ctx, fmt.Sprintf("sites/%s/views/%s/permissions", vs.client.SiteID, id))
+}
+
+// AddPermissions adds the rules in permissions to the view and returns the
+// rules that were added.
+func (vs *viewsService) AddPermissions(ctx context.Context, id string, permissions *Permissions) (*Permissions, error) {
+	return vs.client.addPermissions(ctx, fmt.Sprintf("sites/%s/views/%s/permissions", vs.client.SiteID, id), permissions)
+}
+
+// DeletePermission removes the capabilities of rule from the view.
+func (vs *viewsService) DeletePermission(ctx context.Context, id string, rule *GranteeCapabilities) error {
+	return vs.client.deletePermission(ctx, fmt.Sprintf("sites/%s/views/%s/permissions", vs.client.SiteID, id), rule)
+}
+
+// UsersWithAccess returns the users the permission rules of the view allow
+// to read it, expanding group grantees to their members.
+func (vs *viewsService) UsersWithAccess(ctx context.Context, id string) ([]*User, error) {
+	permissions, err := vs.Permissions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return vs.client.usersWithAccess(ctx, permissions, CapabilityRead)
+}
@@ -0,0 +1,79 @@
+package tableau
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestViewsExportImage(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/views/view-id/image", func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Query().Get("resolution"), qt.Equals, "high")
+		c.Assert(r.URL.Query().Get("vf_Region"), qt.Equals, "West Coast")
+		c.Assert(r.URL.Query().Get("maxAge"), qt.Equals, "5")
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png"))
+	})
+
+	data, contentType, err := client.Views.ExportImage(context.Background(), "view-id",
+		WithImageResolution(ImageResolutionHigh),
+		WithViewFilter("Region", "West Coast"),
+		WithMaxAge(5),
+	)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, "png")
+	c.Assert(contentType, qt.Equals, "image/png")
+}
+
+func TestViewsExportCrosstabExcel(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.14/sites/site-id/views/view-id/crosstab/excel", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		_, _ = w.Write([]byte("xlsx"))
+	}, WithAPIVersion("3.14"))
+
+	data, _, err := client.Views.ExportCrosstabExcel(context.Background(), "view-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, "xlsx")
+
+	oldClient := newTestClient(t, "/api/3.4/sites/site-id/views", func(w http.ResponseWriter, r *http.Request) {
+		c.Errorf("unexpected request to %s", r.URL.Path)
+	})
+	_, _, err = oldClient.Views.ExportCrosstabExcel(context.Background(), "view-id")
+	c.Assert(err, qt.ErrorMatches, `crosstab excel export requires REST API version 3.14 or later.*`)
+}
+
+func TestViewsUsersWithAccess(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/3.4/sites/site-id/views/view-id/permissions":
+			_, _ = w.Write([]byte(`{"permissions": {"granteeCapabilities": [
+				{"group": {"id": "group-id"}, "capabilities": {"capability": [{"name": "Read", "mode": "Allow"}]}},
+				{"user": {"id": "bob"}, "capabilities": {"capability": [{"name": "Read", "mode": "Deny"}]}},
+				{"user": {"id": "carol"}, "capabilities": {"capability": [{"name": "Read", "mode": "Allow"}]}}
+			]}}`))
+		case "/api/3.4/sites/site-id/groups/group-id/users":
+			_, _ = w.Write([]byte(`{
+				"pagination": {"pageNumber": "1", "pageSize": "1000", "totalAvailable": "2"},
+				"users": {"user": [{"id": "alice", "name": "alice"}, {"id": "bob", "name": "bob"}]}
+			}`))
+		case "/api/3.4/sites/site-id/users/carol":
+			_, _ = w.Write([]byte(`{"user": {"id": "carol", "name": "carol"}}`))
+		default:
+			c.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	users, err := client.Views.UsersWithAccess(context.Background(), "view-id")
+	c.Assert(err, qt.IsNil)
+
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Name)
+	}
+	c.Assert(names, qt.DeepEquals, []string{"alice", "carol"})
+}
@@ -0,0 +1,189 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	webhooksMinAPIVersion    = "3.6"
+	webhookSourceEventPrefix = "webhook-source-event-"
+)
+
+// Webhook represents a Tableau webhook
+type Webhook struct {
+	ID      string
+	Name    string
+	Enabled bool
+	// Event is the event that fires the webhook, i.e; "datasource-refresh-failed".
+	Event string
+	// URL is the destination the webhook posts to.
+	URL string
+}
+
+// CreateWebhookRequest encapsulates the request for creating a new webhook.
+type CreateWebhookRequest struct {
+	Name string
+	// Event is the event that fires the webhook, i.e; "datasource-refresh-failed".
+	Event string
+	// URL is the destination the webhook posts to.
+	URL string
+}
+
+// webhookPayload is the wire representation of a webhook, where the event is
+// encoded as the key of the webhook-source element.
+type webhookPayload struct {
+	ID          string              `json:"id,omitempty"`
+	Name        string              `json:"name"`
+	Enabled     bool                `json:"enabled,omitempty"`
+	Source      map[string]struct{} `json:"webhook-source"`
+	Destination struct {
+		HTTP struct {
+			Method string `json:"method"`
+			URL    string `json:"url"`
+		} `json:"webhook-destination-http"`
+	} `json:"webhook-destination"`
+}
+
+func (p *webhookPayload) webhook() *Webhook {
+	w := &Webhook{
+		ID:      p.ID,
+		Name:    p.Name,
+		Enabled: p.Enabled,
+		URL:     p.Destination.HTTP.URL,
+	}
+	for key := range p.Source {
+		w.Event = strings.TrimPrefix(key, webhookSourceEventPrefix)
+	}
+	return w
+}
+
+type webhookResponse struct {
+	Webhook *webhookPayload `json:"webhook"`
+}
+
+type queryWebhooksResponse struct {
+	Webhooks struct {
+		Webhook []*webhookPayload `json:"webhook"`
+	} `json:"webhooks"`
+}
+
+type webhooksService struct {
+	client *Client
+}
+
+func (ws *webhooksService) Create(ctx context.Context, createReq *CreateWebhookRequest) (*Webhook, error) {
+	err := ws.client.requireAPIVersion(webhooksMinAPIVersion, "webhooks")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/webhooks", ws.client.SiteID)
+
+	payload := &webhookPayload{
+		Name: createReq.Name,
+		Source: map[string]struct{}{
+			webhookSourceEventPrefix + createReq.Event: {},
+		},
+	}
+	payload.Destination.HTTP.Method = http.MethodPost
+	payload.Destination.HTTP.URL = createReq.URL
+
+	request := struct {
+		Webhook *webhookPayload `json:"webhook"`
+	}{
+		Webhook: payload,
+	}
+
+	req, err := ws.client.newRequest(http.MethodPost, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for create webhook")
+	}
+
+	resp := &webhookResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Webhook.webhook(), nil
+}
+
+func (ws *webhooksService) Query(ctx context.Context) ([]*Webhook, error) {
+	err := ws.client.requireAPIVersion(webhooksMinAPIVersion, "webhooks")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/webhooks", ws.client.SiteID)
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query webhooks")
+	}
+
+	resp := &queryWebhooksResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*Webhook, 0, len(resp.Webhooks.Webhook))
+	for _, w := range resp.Webhooks.Webhook {
+		webhooks = append(webhooks, w.webhook())
+	}
+	return webhooks, nil
+}
+
+func (ws *webhooksService) Get(ctx context.Context, id string) (*Webhook, error) {
+	err := ws.client.requireAPIVersion(webhooksMinAPIVersion, "webhooks")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("sites/%s/webhooks/%s", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get webhook")
+	}
+
+	resp := &webhookResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Webhook.webhook(), nil
+}
+
+func (ws *webhooksService) Delete(ctx context.Context, id string) error {
+	err := ws.client.requireAPIVersion(webhooksMinAPIVersion, "webhooks")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/webhooks/%s", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete webhook")
+	}
+	err = ws.client.do(ctx, req, nil)
+	return err
+}
+
+// Test makes the server send a test payload to the webhook destination.
+// Tableau exposes this as a GET on the webhook's test resource.
+func (ws *webhooksService) Test(ctx context.Context, id string) error {
+	err := ws.client.requireAPIVersion(webhooksMinAPIVersion, "webhooks")
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("sites/%s/webhooks/%s/test", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for test webhook")
+	}
+	err = ws.client.do(ctx, req, nil)
+	return err
+}
@@ -0,0 +1,510 @@
+package tableau
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DataFreshnessOption represents how fresh the data of a workbook is kept.
+type DataFreshnessOption string
+
+const (
+	DataFreshnessAlwaysLive  DataFreshnessOption = "AlwaysLive"
+	DataFreshnessSiteDefault DataFreshnessOption = "SiteDefault"
+	DataFreshnessFreshEvery  DataFreshnessOption = "FreshEvery"
+	DataFreshnessFreshAt     DataFreshnessOption = "FreshAt"
+)
+
+// DataFreshnessPolicy represents the cache policy of a workbook. FreshEvery
+// is set for the FreshEvery option and FreshAt for the FreshAt option.
+type DataFreshnessPolicy struct {
+	Option     DataFreshnessOption `json:"option"`
+	FreshEvery *FreshEverySchedule `json:"freshEverySchedule,omitempty"`
+	FreshAt    *FreshAtSchedule    `json:"freshAtSchedule,omitempty"`
+}
+
+// FreshEverySchedule keeps data at most Value units of Frequency old, where
+// Frequency is one of "Minutes", "Hours", "Days" or "Weeks".
+type FreshEverySchedule struct {
+	Frequency string `json:"frequency"`
+	Value     string `json:"value"`
+}
+
+// FreshAtSchedule refreshes data at Time, i.e; "14:00:00", in Timezone with
+// the given Frequency, one of "Day", "Week" or "Month".
+type FreshAtSchedule struct {
+	Frequency string             `json:"frequency"`
+	Time      string             `json:"time"`
+	Timezone  string             `json:"timezone"`
+	Intervals *ScheduleIntervals `json:"intervals,omitempty"`
+}
+
+// Workbook represents a Tableau workbook
+type Workbook struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	ContentUrl      string `json:"contentUrl"`
+	WebpageUrl      string `json:"webpageUrl"`
+	ShowTabs        bool   `json:"showTabs"`
+	Size            int    `json:"size,string"`
+	EncryptExtracts string `json:"encryptExtracts"`
+	DefaultViewID   string `json:"defaultViewId"`
+	Project         struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	Owner struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	Tags  Tags `json:"tags"`
+	Views struct {
+		View []*View `json:"view"`
+	} `json:"views"`
+	DataFreshnessPolicy *DataFreshnessPolicy `json:"dataFreshnessPolicy"`
+	CreatedAt           time.Time            `json:"createdAt"`
+	UpdatedAt           time.Time            `json:"updatedAt"`
+}
+
+// PublishWorkbookRequest encapsulates the request for publishing a workbook.
+type PublishWorkbookRequest struct {
+	Name string
+	// FileName is the name of the uploaded file. Its extension, ".twb" or
+	// ".twbx", decides the type of the workbook.
+	FileName  string
+	ProjectID string
+	ShowTabs  bool
+	// Overwrite replaces an existing workbook with the same name.
+	Overwrite bool
+}
+
+type publishWorkbookPayload struct {
+	Name     string     `json:"name"`
+	ShowTabs bool       `json:"showTabs"`
+	Project  resourceID `json:"project"`
+}
+
+// UpdateWorkbookRequest encapsulates the request for updating a workbook.
+// Empty fields are left unchanged on the server.
+type UpdateWorkbookRequest struct {
+	ID           string
+	Name         string
+	NewProjectID string
+	OwnerID      string
+	ShowTabs     *bool
+	// DataFreshnessPolicy requires REST API version 3.21.
+	DataFreshnessPolicy *DataFreshnessPolicy
+}
+
+// updateWorkbookPayload is the wire representation of UpdateWorkbookRequest.
+type updateWorkbookPayload struct {
+	Name     string      `json:"name,omitempty"`
+	ShowTabs *bool       `json:"showTabs,omitempty"`
+	Project  *resourceID `json:"project,omitempty"`
+	Owner    *resourceID `json:"owner,omitempty"`
+
+	DataFreshnessPolicy *DataFreshnessPolicy `json:"dataFreshnessPolicy,omitempty"`
+}
+
+// WorkbookRevision represents a published revision of a workbook.
+type WorkbookRevision = Revision
+
+type workbookResponse struct {
+	Workbook *Workbook `json:"workbook"`
+}
+
+type queryWorkbooksResponse struct {
+	Pagination Pagination
+	Workbooks  struct {
+		Workbook []*Workbook `json:"workbook"`
+	} `json:"workbooks"`
+}
+
+type workbooksService struct {
+	client *Client
+}
+
+func (ws *workbooksService) Query(ctx context.Context, opts ...QueryOption) ([]*Workbook, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/workbooks", ws.client.SiteID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query workbooks")
+	}
+
+	resp := &queryWorkbooksResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Workbooks.Workbook, nil
+}
+
+func (ws *workbooksService) Get(ctx context.Context, id string) (*Workbook, error) {
+	path := fmt.Sprintf("sites/%s/workbooks/%s", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for get workbook")
+	}
+
+	resp := &workbookResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Workbook, nil
+}
+
+// Publish uploads a workbook read from r in a single request. Tableau limits
+// single request publishing to files of 64 MB.
+func (ws *workbooksService) Publish(ctx context.Context, publishReq *PublishWorkbookRequest, r io.Reader) (*Workbook, error) {
+	workbookType := strings.TrimPrefix(strings.ToLower(filepath.Ext(publishReq.FileName)), ".")
+	if workbookType != "twb" && workbookType != "twbx" {
+		return nil, errors.Errorf("unsupported workbook file %q, expected a .twb or .twbx file", publishReq.FileName)
+	}
+
+	query := url.Values{}
+	query.Set("workbookType", workbookType)
+	if publishReq.Overwrite {
+		query.Set("overwrite", "true")
+	}
+	path := fmt.Sprintf("sites/%s/workbooks?%s", ws.client.SiteID, query.Encode())
+
+	request := struct {
+		Workbook publishWorkbookPayload `json:"workbook"`
+	}{
+		Workbook: publishWorkbookPayload{
+			Name:     publishReq.Name,
+			ShowTabs: publishReq.ShowTabs,
+			Project:  resourceID{ID: publishReq.ProjectID},
+		},
+	}
+
+	req, err := ws.client.newMultipartRequest(http.MethodPost, path, request, "tableau_workbook", publishReq.FileName, r)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for publish workbook")
+	}
+
+	resp := &workbookResponse{}
+	err = ws.client.send(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Workbook, nil
+}
+
+func (ws *workbooksService) Update(ctx context.Context, updateReq *UpdateWorkbookRequest) (*Workbook, error) {
+	path := fmt.Sprintf("sites/%s/workbooks/%s", ws.client.SiteID, updateReq.ID)
+
+	if updateReq.DataFreshnessPolicy != nil {
+		err := ws.client.requireAPIVersion("3.21", "workbook data freshness policy")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	payload := &updateWorkbookPayload{
+		Name:                updateReq.Name,
+		ShowTabs:            updateReq.ShowTabs,
+		DataFreshnessPolicy: updateReq.DataFreshnessPolicy,
+	}
+	if updateReq.NewProjectID != "" {
+		payload.Project = &resourceID{ID: updateReq.NewProjectID}
+	}
+	if updateReq.OwnerID != "" {
+		payload.Owner = &resourceID{ID: updateReq.OwnerID}
+	}
+
+	request := struct {
+		Workbook *updateWorkbookPayload `json:"workbook"`
+	}{
+		Workbook: payload,
+	}
+	req, err := ws.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update workbook")
+	}
+
+	resp := &workbookResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Workbook, nil
+}
+
+// GetDataFreshnessPolicy returns the data freshness policy of the workbook.
+func (ws *workbooksService) GetDataFreshnessPolicy(ctx context.Context, id string) (*DataFreshnessPolicy, error) {
+	err := ws.client.requireAPIVersion("3.21", "workbook data freshness policy")
+	if err != nil {
+		return nil, err
+	}
+
+	wb, err := ws.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return wb.DataFreshnessPolicy, nil
+}
+
+// UpdateDataFreshnessPolicy sets the data freshness policy of the workbook.
+func (ws *workbooksService) UpdateDataFreshnessPolicy(ctx context.Context, id string, policy *DataFreshnessPolicy) (*DataFreshnessPolicy, error) {
+	wb, err := ws.Update(ctx, &UpdateWorkbookRequest{
+		ID:                  id,
+		DataFreshnessPolicy: policy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wb.DataFreshnessPolicy, nil
+}
+
+// Download writes the content of the workbook, a .twb or .twbx file, to w.
+func (ws *workbooksService) Download(ctx context.Context, id string, w io.Writer) error {
+	path := fmt.Sprintf("sites/%s/workbooks/%s/content", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for download workbook")
+	}
+
+	_, err = ws.client.download(ctx, req, w)
+	return err
+}
+
+func (ws *workbooksService) Delete(ctx context.Context, id string) error {
+	path := fmt.Sprintf("sites/%s/workbooks/%s", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete workbook")
+	}
+	err = ws.client.do(ctx, req, nil)
+	return err
+}
+
+func (ws *workbooksService) ListRevisions(ctx context.Context, id string) ([]*WorkbookRevision, error) {
+	path := fmt.Sprintf("sites/%s/workbooks/%s/revisions", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for list workbook revisions")
+	}
+
+	resp := &revisionsResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Revisions.Revision, nil
+}
+
+// DownloadRevision returns the content of the given revision of a workbook.
+// The caller must close the returned reader.
+func (ws *workbooksService) DownloadRevision(ctx context.Context, id string, revision int) (io.ReadCloser, error) {
+	path := fmt.Sprintf("sites/%s/workbooks/%s/revisions/%d/content", ws.client.SiteID, id, revision)
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for download workbook revision")
+	}
+
+	res, err := ws.client.doStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Body, nil
+}
+
+func (ws *workbooksService) DeleteRevision(ctx context.Context, id string, revision int) error {
+	path := fmt.Sprintf("sites/%s/workbooks/%s/revisions/%d", ws.client.SiteID, id, revision)
+	req, err := ws.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request for delete workbook revision")
+	}
+	err = ws.client.do(ctx, req, nil)
+	return err
+}
+
+// ExportPDF renders the workbook as a PDF document. It returns the document
+// along with the Content-Type reported by the server.
+func (ws *workbooksService) ExportPDF(ctx context.Context, id string, opts ...ExportOption) ([]byte, string, error) {
+	return ws.client.export(ctx, fmt.Sprintf("sites/%s/workbooks/%s/pdf", ws.client.SiteID, id), opts)
+}
+
+// ExportPowerPoint renders the workbook as a PowerPoint presentation with a
+// slide per sheet. It returns the presentation along with the Content-Type
+// reported by the server.
+func (ws *workbooksService) ExportPowerPoint(ctx context.Context, id string, opts ...ExportOption) ([]byte, string, error) {
+	err := ws.client.requireAPIVersion("3.8", "workbook PowerPoint export")
+	if err != nil {
+		return nil, "", err
+	}
+
+	return ws.client.export(ctx, fmt.Sprintf("sites/%s/workbooks/%s/powerpoint", ws.client.SiteID, id), opts)
+}
+
+// PreviewImage returns the thumbnail image of the workbook along with the
+// Content-Type reported by the server.
+func (ws *workbooksService) PreviewImage(ctx context.Context, id string) ([]byte, string, error) {
+	return ws.client.export(ctx, fmt.Sprintf("sites/%s/workbooks/%s/previewImage", ws.client.SiteID, id), nil)
+}
+
+func (ws *workbooksService) Connections(ctx context.Context, id string) ([]*Connection, error) {
+	path := fmt.Sprintf("sites/%s/workbooks/%s/connections", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for list workbook connections")
+	}
+
+	resp := &connectionsResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Connections.Connection, nil
+}
+
+func (ws *workbooksService) UpdateConnection(ctx context.Context, workbookID, connectionID string, updateReq *UpdateConnectionRequest) (*Connection, error) {
+	path := fmt.Sprintf("sites/%s/workbooks/%s/connections/%s", ws.client.SiteID, workbookID, connectionID)
+
+	request := struct {
+		Connection *UpdateConnectionRequest `json:"connection"`
+	}{
+		Connection: updateReq,
+	}
+	req, err := ws.client.newRequest(http.MethodPut, path, request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for update workbook connection")
+	}
+
+	resp := &connectionResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Connection, nil
+}
+
+// RefreshNow starts an extract refresh of the workbook and returns the job
+// running it.
+func (ws *workbooksService) RefreshNow(ctx context.Context, id string) (*Job, error) {
+	path := fmt.Sprintf("sites/%s/workbooks/%s/refresh", ws.client.SiteID, id)
+	req, err := ws.client.newRequest(http.MethodPost, path, struct{}{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for refresh workbook")
+	}
+
+	resp := &jobResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Job, nil
+}
+
+// Views returns the views, sheets and dashboards, of the workbook.
+func (ws *workbooksService) Views(ctx context.Context, workbookID string, opts ...QueryOption) ([]*View, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/workbooks/%s/views", ws.client.SiteID, workbookID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query workbook views")
+	}
+
+	resp := &queryViewsResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Views.View, nil
+}
+
+// ForUser returns the workbooks the user can read. Use WithOwnedBy to only
+// return the workbooks the user owns.
+func (ws *workbooksService) ForUser(ctx context.Context, userID string, opts ...QueryOption) ([]*Workbook, error) {
+	path, err := applyQueryOptions(fmt.Sprintf("sites/%s/users/%s/workbooks", ws.client.SiteID, userID), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := ws.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request for query workbooks for user")
+	}
+
+	resp := &queryWorkbooksResponse{}
+	err = ws.client.do(ctx, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Workbooks.Workbook, nil
+}
+
+// WithOwnedBy returns a QueryOption that sets the "ownedBy" URL parameter.
+func WithOwnedBy(ownedBy bool) QueryOption {
+	return func(opt *QueryOptions) error {
+		if ownedBy {
+			opt.URLValues.Set("ownedBy", "true")
+		}
+		return nil
+	}
+}
+
+// AddTags adds tags to the workbook and returns the tags that were added.
+func (ws *workbooksService) AddTags(ctx context.Context, id string, tags []string) ([]*Tag, error) {
+	return ws.client.addTags(ctx, fmt.Sprintf("sites/%s/workbooks/%s/tags", ws.client.SiteID, id), tags)
+}
+
+func (ws *workbooksService) DeleteTag(ctx context.Context, id, tag string) error {
+	return ws.client.deleteTag(ctx, fmt.Sprintf("sites/%s/workbooks/%s/tags", ws.client.SiteID, id), tag)
+}
+
+// Permissions returns the explicit permissions set on the workbook.
+func (ws *workbooksService) Permissions(ctx context.Context, id string) (*Permissions, error) {
+	return ws.client.permissions(ctx, fmt.Sprintf("sites/%s/workbooks/%s/permissions", ws.client.SiteID, id))
+}
+
+// AddPermissions adds the rules in permissions to the workbook and returns the
+// rules that were added.
+func (ws *workbooksService) AddPermissions(ctx context.Context, id string, permissions *Permissions) (*Permissions, error) {
+	return ws.client.addPermissions(ctx, fmt.Sprintf("sites/%s/workbooks/%s/permissions", ws.client.SiteID, id), permissions)
+}
+
+// DeletePermission removes the capabilities of rule from the workbook.
+func (ws *workbooksService) DeletePermission(ctx context.Context, id string, rule *GranteeCapabilities) error {
+	return ws.client.deletePermission(ctx, fmt.Sprintf("sites/%s/workbooks/%s/permissions", ws.client.SiteID, id), rule)
+}
+
+// UsersWithAccess returns the users the permission rules of the workbook allow
+// to read it, expanding group grantees to their members.
+func (ws *workbooksService) UsersWithAccess(ctx context.Context, id string) ([]*User, error) {
+	permissions, err := ws.Permissions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ws.client.usersWithAccess(ctx, permissions, CapabilityRead)
+}
@@ -0,0 +1,385 @@
+package tableau
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestWorkbooksQueryAndGet(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodGet)
+		switch r.URL.Path {
+		case "/api/3.4/sites/site-id/workbooks":
+			c.Check(r.URL.Query().Get("pageSize"), qt.Equals, "2")
+			_, _ = w.Write([]byte(`{
+				"pagination": {"pageNumber": "1", "pageSize": "2", "totalAvailable": "2"},
+				"workbooks": {"workbook": [{"id": "a", "name": "sales"}, {"id": "b", "name": "finance"}]}
+			}`))
+		case "/api/3.4/sites/site-id/workbooks/a":
+			_, _ = w.Write([]byte(`{"workbook": {"id": "a", "name": "sales", "size": "12", "project": {"id": "project-id", "name": "default"}}}`))
+		default:
+			c.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	workbooks, err := client.Workbooks.Query(context.Background(), WithPageSize(2))
+	c.Assert(err, qt.IsNil)
+	c.Assert(workbooks, qt.HasLen, 2)
+	c.Assert(workbooks[1].Name, qt.Equals, "finance")
+
+	wb, err := client.Workbooks.Get(context.Background(), "a")
+	c.Assert(err, qt.IsNil)
+	c.Assert(wb.Size, qt.Equals, 12)
+	c.Assert(wb.Project.Name, qt.Equals, "default")
+}
+
+func TestWorkbooksPublish(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks", func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Query().Get("workbookType"), qt.Equals, "twbx")
+		c.Assert(r.URL.Query().Get("overwrite"), qt.Equals, "true")
+
+		mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		c.Assert(err, qt.IsNil)
+		c.Assert(mediaType, qt.Equals, "multipart/mixed")
+
+		mr := multipart.NewReader(r.Body, params["boundary"])
+
+		part, err := mr.NextPart()
+		c.Assert(err, qt.IsNil)
+		c.Assert(part.Header.Get("Content-Disposition"), qt.Equals, `name="request_payload"`)
+		payload, err := ioutil.ReadAll(part)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(payload), qt.Equals, `{"workbook":{"name":"sales","showTabs":true,"project":{"id":"project-id"}}}`+"\n")
+
+		part, err = mr.NextPart()
+		c.Assert(err, qt.IsNil)
+		c.Assert(part.Header.Get("Content-Disposition"), qt.Equals, `name="tableau_workbook"; filename="sales.twbx"`)
+		content, err := ioutil.ReadAll(part)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(content), qt.Equals, "workbook content")
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"workbook": {"id": "workbook-id", "name": "sales"}}`))
+	})
+
+	wb, err := client.Workbooks.Publish(context.Background(), &PublishWorkbookRequest{
+		Name:      "sales",
+		FileName:  "sales.twbx",
+		ProjectID: "project-id",
+		ShowTabs:  true,
+		Overwrite: true,
+	}, strings.NewReader("workbook content"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(wb.ID, qt.Equals, "workbook-id")
+}
+
+func TestWorkbooksDownload(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id/content", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodGet)
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("workbook"))
+	})
+
+	var buf bytes.Buffer
+	err := client.Workbooks.Download(context.Background(), "workbook-id", &buf)
+	c.Assert(err, qt.IsNil)
+	c.Assert(buf.String(), qt.Equals, "workbook")
+}
+
+func TestWorkbooksUpdate(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPut)
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+			"workbook": map[string]interface{}{
+				"name":     "sales",
+				"showTabs": false,
+				"project":  map[string]interface{}{"id": "project-id"},
+			},
+		})
+		_, _ = w.Write([]byte(`{"workbook": {"id": "workbook-id", "name": "sales"}}`))
+	})
+
+	showTabs := false
+	wb, err := client.Workbooks.Update(context.Background(), &UpdateWorkbookRequest{
+		ID:           "workbook-id",
+		Name:         "sales",
+		NewProjectID: "project-id",
+		ShowTabs:     &showTabs,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(wb.Name, qt.Equals, "sales")
+}
+
+func TestWorkbooksDelete(t *testing.T) {
+	c := qt.New(t)
+	deleted := false
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodDelete)
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := client.Workbooks.Delete(context.Background(), "workbook-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+}
+
+func TestWorkbooksRevisions(t *testing.T) {
+	c := qt.New(t)
+	var deleted []string
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id/revisions", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/3.4/sites/site-id/workbooks/workbook-id/revisions":
+			_, _ = w.Write([]byte(`{"revisions": {"revision": [
+				{"revisionNumber": "1", "current": false},
+				{"revisionNumber": "2", "current": true}
+			]}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/3.4/sites/site-id/workbooks/workbook-id/revisions/1/content":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("revision 1"))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			c.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	revisions, err := client.Workbooks.ListRevisions(context.Background(), "workbook-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(revisions, qt.HasLen, 2)
+	c.Assert(revisions[1].RevisionNumber, qt.Equals, 2)
+	c.Assert(revisions[1].Current, qt.IsTrue)
+
+	rc, err := client.Workbooks.DownloadRevision(context.Background(), "workbook-id", 1)
+	c.Assert(err, qt.IsNil)
+	defer rc.Close()
+	content, err := ioutil.ReadAll(rc)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(content), qt.Equals, "revision 1")
+
+	err = client.Workbooks.DeleteRevision(context.Background(), "workbook-id", 1)
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.DeepEquals, []string{"/api/3.4/sites/site-id/workbooks/workbook-id/revisions/1"})
+}
+
+func TestWorkbooksExportPDF(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id/pdf", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Query().Get("type"), qt.Equals, "A4")
+		c.Check(r.URL.Query().Get("orientation"), qt.Equals, "Landscape")
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("pdf"))
+	})
+
+	data, contentType, err := client.Workbooks.ExportPDF(context.Background(), "workbook-id",
+		WithPDFPageType(PDFPageTypeA4),
+		WithPDFOrientation(PDFOrientationLandscape),
+	)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, "pdf")
+	c.Assert(contentType, qt.Equals, "application/pdf")
+}
+
+func TestWorkbooksExportPowerPoint(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.8/sites/site-id/workbooks/workbook-id/powerpoint", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.presentationml.presentation")
+		_, _ = w.Write([]byte("pptx"))
+	}, WithAPIVersion("3.8"))
+
+	data, _, err := client.Workbooks.ExportPowerPoint(context.Background(), "workbook-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, "pptx")
+
+	oldClient := newTestClient(t, "/api/3.4/sites/site-id/workbooks", func(w http.ResponseWriter, r *http.Request) {
+		c.Errorf("unexpected request to %s", r.URL.Path)
+	})
+	_, _, err = oldClient.Workbooks.ExportPowerPoint(context.Background(), "workbook-id")
+	c.Assert(err, qt.ErrorMatches, `workbook PowerPoint export requires REST API version 3.8 or later.*`)
+}
+
+func TestWorkbooksPreviewImage(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id/previewImage", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodGet)
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte("png"))
+	})
+
+	data, contentType, err := client.Workbooks.PreviewImage(context.Background(), "workbook-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, "png")
+	c.Assert(contentType, qt.Equals, "image/png")
+}
+
+func TestWorkbooksConnections(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id/connections", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/3.4/sites/site-id/workbooks/workbook-id/connections":
+			_, _ = w.Write([]byte(`{"connections": {"connection": [
+				{"id": "conn-id", "type": "postgres", "serverAddress": "db.example.com", "serverPort": "5432", "userName": "etl"}
+			]}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/3.4/sites/site-id/workbooks/workbook-id/connections/conn-id":
+			body, err := ioutil.ReadAll(r.Body)
+			c.Check(err, qt.IsNil)
+			c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+				"connection": map[string]interface{}{
+					"serverAddress": "replica.example.com",
+					"embedPassword": false,
+				},
+			})
+			_, _ = w.Write([]byte(`{"connection": {"id": "conn-id", "serverAddress": "replica.example.com"}}`))
+		default:
+			c.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	conns, err := client.Workbooks.Connections(context.Background(), "workbook-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(conns, qt.HasLen, 1)
+	c.Assert(conns[0].ServerPort, qt.Equals, "5432")
+
+	embed := false
+	conn, err := client.Workbooks.UpdateConnection(context.Background(), "workbook-id", "conn-id", &UpdateConnectionRequest{
+		ServerAddress: "replica.example.com",
+		EmbedPassword: &embed,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(conn.ServerAddress, qt.Equals, "replica.example.com")
+}
+
+func TestWorkbooksRefreshNow(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id/refresh", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPost)
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(string(body), qt.JSONEquals, map[string]interface{}{})
+		_, _ = w.Write([]byte(`{"job": {"id": "job-id", "mode": "Asynchronous", "type": "RefreshExtract"}}`))
+	})
+
+	job, err := client.Workbooks.RefreshNow(context.Background(), "workbook-id")
+	c.Assert(err, qt.IsNil)
+	c.Assert(job.ID, qt.Equals, "job-id")
+}
+
+func TestWorkbooksViews(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id/views", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodGet)
+		c.Check(r.URL.Query().Get("pageSize"), qt.Equals, "10")
+		_, _ = w.Write([]byte(`{"views": {"view": [{"id": "view-id", "name": "Overview"}]}}`))
+	})
+
+	views, err := client.Workbooks.Views(context.Background(), "workbook-id", WithPageSize(10))
+	c.Assert(err, qt.IsNil)
+	c.Assert(views, qt.HasLen, 1)
+	c.Assert(views[0].Name, qt.Equals, "Overview")
+}
+
+func TestWorkbooksForUser(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.4/sites/site-id/users/user-id/workbooks", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodGet)
+		c.Check(r.URL.Query().Get("ownedBy"), qt.Equals, "true")
+		_, _ = w.Write([]byte(`{"workbooks": {"workbook": [{"id": "workbook-id", "name": "sales"}]}}`))
+	})
+
+	workbooks, err := client.Workbooks.ForUser(context.Background(), "user-id", WithOwnedBy(true))
+	c.Assert(err, qt.IsNil)
+	c.Assert(workbooks, qt.HasLen, 1)
+	c.Assert(workbooks[0].ID, qt.Equals, "workbook-id")
+}
+
+func TestWorkbooksTags(t *testing.T) {
+	c := qt.New(t)
+	var deleted []string
+	client := newTestClient(t, "/api/3.4/sites/site-id/workbooks/workbook-id/tags", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body, err := ioutil.ReadAll(r.Body)
+			c.Check(err, qt.IsNil)
+			c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+				"tags": map[string]interface{}{
+					"tag": []interface{}{
+						map[string]interface{}{"label": "finance"},
+						map[string]interface{}{"label": "needs review"},
+					},
+				},
+			})
+			_, _ = w.Write([]byte(`{"tags": {"tag": [{"label": "finance"}, {"label": "needs review"}]}}`))
+		case http.MethodDelete:
+			deleted = append(deleted, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			c.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	tags, err := client.Workbooks.AddTags(context.Background(), "workbook-id", []string{"finance", "needs review"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(tags, qt.HasLen, 2)
+
+	err = client.Workbooks.DeleteTag(context.Background(), "workbook-id", "needs review")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.DeepEquals, []string{"/api/3.4/sites/site-id/workbooks/workbook-id/tags/needs%20review"})
+}
+
+func TestWorkbooksDataFreshnessPolicy(t *testing.T) {
+	c := qt.New(t)
+	client := newTestClient(t, "/api/3.21/sites/site-id/workbooks/workbook-id", func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, qt.Equals, http.MethodPut)
+		body, err := ioutil.ReadAll(r.Body)
+		c.Check(err, qt.IsNil)
+		c.Check(string(body), qt.JSONEquals, map[string]interface{}{
+			"workbook": map[string]interface{}{
+				"dataFreshnessPolicy": map[string]interface{}{
+					"option": "FreshAt",
+					"freshAtSchedule": map[string]interface{}{
+						"frequency": "Week",
+						"time":      "06:00:00",
+						"timezone":  "Europe/Berlin",
+						"intervals": map[string]interface{}{
+							"interval": []interface{}{map[string]interface{}{"weekDay": "Monday"}},
+						},
+					},
+				},
+			},
+		})
+		_, _ = w.Write([]byte(`{"workbook": {"id": "workbook-id", "dataFreshnessPolicy": {"option": "FreshAt"}}}`))
+	}, WithAPIVersion("3.21"))
+
+	policy, err := client.Workbooks.UpdateDataFreshnessPolicy(context.Background(), "workbook-id", &DataFreshnessPolicy{
+		Option: DataFreshnessFreshAt,
+		FreshAt: &FreshAtSchedule{
+			Frequency: "Week",
+			Time:      "06:00:00",
+			Timezone:  "Europe/Berlin",
+			Intervals: &ScheduleIntervals{Interval: []*ScheduleInterval{{WeekDay: "Monday"}}},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(policy.Option, qt.Equals, DataFreshnessFreshAt)
+
+	oldClient := newTestClient(t, "/api/3.4/sites/site-id/workbooks", func(w http.ResponseWriter, r *http.Request) {
+		c.Errorf("unexpected request to %s", r.URL.Path)
+	})
+	_, err = oldClient.Workbooks.GetDataFreshnessPolicy(context.Background(), "workbook-id")
+	c.Assert(err, qt.ErrorMatches, `workbook data freshness policy requires REST API version 3.21 or later.*`)
+}